@@ -14,6 +14,9 @@
 package commands
 
 import (
+	"os"
+	"path/filepath"
+
 	"github.com/gohugoio/hugo/config"
 	"github.com/gohugoio/hugo/helpers"
 	"github.com/spf13/cobra"
@@ -45,6 +48,7 @@ func (b *commandsBuilder) addAll() *commandsBuilder {
 		newCheckCmd(),
 		b.newBenchmarkCmd(),
 		newConvertCmd(),
+		newEncryptCmd(),
 		newNewCmd(),
 		newListCmd(),
 		newImportCmd(),
@@ -111,6 +115,9 @@ func (c *baseCmd) flagsToConfig(cfg config.Provider) {
 type hugoCmd struct {
 	*baseBuilderCmd
 
+	// The build profile to write, one of cpu, mem or trace.
+	profile string
+
 	// Need to get the sites once built.
 	c *commandeer
 }
@@ -154,11 +161,27 @@ Complete documentation is available at http://gohugo.io/.`,
 			}
 			cc.c = c
 
+			if cc.profile != "" {
+				filename := profileFilenames[cc.profile]
+				stopProfile, err := startProfile(cc.profile, filename)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					if err := stopProfile(); err != nil {
+						c.Logger.ERROR.Printf("Failed to write %s profile: %s", cc.profile, err)
+						return
+					}
+					c.Logger.FEEDBACK.Printf("Wrote %s profile to %s\n", cc.profile, filename)
+				}()
+			}
+
 			return c.build()
 		},
 	})
 
 	cc.cmd.PersistentFlags().StringVar(&cc.cfgFile, "config", "", "config file (default is path/config.yaml|json|toml)")
+	cc.cmd.PersistentFlags().StringVar(&cc.cfgDir, "configDir", "config", "config dir")
 	cc.cmd.PersistentFlags().BoolVar(&cc.quiet, "quiet", false, "build in quiet mode")
 
 	// Set bash-completion
@@ -174,6 +197,7 @@ Complete documentation is available at http://gohugo.io/.`,
 	cc.cmd.Flags().BoolVarP(&cc.buildWatch, "watch", "w", false, "watch filesystem for changes and recreate as needed")
 
 	cc.cmd.Flags().Bool("renderToMemory", false, "render to memory (only useful for benchmark testing)")
+	cc.cmd.Flags().StringVar(&cc.profile, "profile", "", "write a profile of the build to the current directory, one of cpu (cpu.pprof), mem (mem.pprof) or trace (trace.out)")
 
 	// Set bash-completion
 	_ = cc.cmd.PersistentFlags().SetAnnotation("logFile", cobra.BashCompFilenameExt, []string{})
@@ -190,7 +214,10 @@ type hugoBuilderCommon struct {
 
 	buildWatch bool
 
-	gc bool
+	environment string
+
+	gc       bool
+	gcDryRun bool
 
 	// TODO(bep) var vs string
 	logging    bool
@@ -200,15 +227,44 @@ type hugoBuilderCommon struct {
 	quiet      bool
 
 	cfgFile string
+	cfgDir  string
 	logFile string
 }
 
+func (cc *hugoBuilderCommon) getConfigDir(baseDir string) string {
+	if cc.cfgDir == "" {
+		return ""
+	}
+	if filepath.IsAbs(cc.cfgDir) {
+		return cc.cfgDir
+	}
+	return filepath.Join(baseDir, cc.cfgDir)
+}
+
+func (cc *hugoBuilderCommon) getEnvironment(isServer bool) string {
+	if cc.environment != "" {
+		return cc.environment
+	}
+
+	if v, found := os.LookupEnv("HUGO_ENVIRONMENT"); found {
+		return v
+	}
+
+	if isServer {
+		return helpers.EnvironmentDevelopment
+	}
+
+	// Leave it to the config.
+	return ""
+}
+
 func (cc *hugoBuilderCommon) handleFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("cleanDestinationDir", false, "remove files from destination not found in static directories")
 	cmd.Flags().BoolP("buildDrafts", "D", false, "include content marked as draft")
 	cmd.Flags().BoolP("buildFuture", "F", false, "include content with publishdate in the future")
 	cmd.Flags().BoolP("buildExpired", "E", false, "include expired content")
 	cmd.Flags().StringVarP(&cc.source, "source", "s", "", "filesystem path to read files relative from")
+	cmd.Flags().StringVarP(&cc.environment, "environment", "e", "", "build environment (defaults to \"development\" for the server and \"production\" otherwise)")
 	cmd.Flags().StringP("contentDir", "c", "", "filesystem path to content directory")
 	cmd.Flags().StringP("layoutDir", "l", "", "filesystem path to layout directory")
 	cmd.Flags().StringP("cacheDir", "", "", "filesystem path to cache directory. Defaults: $TMPDIR/hugo_cache/")
@@ -220,11 +276,16 @@ func (cc *hugoBuilderCommon) handleFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("canonifyURLs", false, "(deprecated) if true, all relative URLs will be canonicalized using baseURL")
 	cmd.Flags().StringVarP(&cc.baseURL, "baseURL", "b", "", "hostname (and path) to the root, e.g. http://spf13.com/")
 	cmd.Flags().Bool("enableGitInfo", false, "add Git revision, date and author info to the pages")
-	cmd.Flags().BoolVar(&cc.gc, "gc", false, "enable to run some cleanup tasks (remove unused cache files) after the build")
+	cmd.Flags().BoolVar(&cc.gc, "gc", false, "enable to run some cleanup tasks (remove unused cache files and stale published files) after the build")
+	cmd.Flags().BoolVar(&cc.gcDryRun, "dryRun", false, "list the files that --gc would remove without removing them")
 
 	cmd.Flags().BoolVar(&nitro.AnalysisOn, "stepAnalysis", false, "display memory and timing of different steps of the program")
 	cmd.Flags().Bool("templateMetrics", false, "display metrics about template executions")
 	cmd.Flags().Bool("templateMetricsHints", false, "calculate some improvement hints when combined with --templateMetrics")
+	cmd.Flags().Bool("printPathWarnings", false, "print warnings on pages written to the same target path")
+	cmd.Flags().Bool("printUnusedTemplates", false, "print warnings on unused templates")
+	cmd.Flags().Bool("printMemoryUsage", false, "print memory usage to screen at intervals")
+	cmd.Flags().String("contentEncryptionKey", "", "the key to decrypt encrypted content files with, see hugo encrypt")
 	cmd.Flags().Bool("pluralizeListTitles", true, "(deprecated) pluralize titles in lists using inflect")
 	cmd.Flags().Bool("preserveTaxonomyNames", false, `(deprecated) preserve taxonomy names as written ("Gérard Depardieu" vs "gerard-depardieu")`)
 	cmd.Flags().BoolP("forceSyncStatic", "", false, "copy all files when static is changed.")
@@ -233,6 +294,7 @@ func (cc *hugoBuilderCommon) handleFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolP("i18n-warnings", "", false, "print missing translations")
 
 	cmd.Flags().StringSlice("disableKinds", []string{}, "disable different kind of pages (home, RSS etc.)")
+	cmd.Flags().StringSlice("renderSegments", []string{}, "render only the given top-level sections, e.g. blog,docs")
 
 	// Set bash-completion.
 	// Each flag must first be defined before using the SetAnnotation() call.
@@ -14,6 +14,7 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -44,6 +45,35 @@ func TestExecute(t *testing.T) {
 	assert.True(len(result.Sites[0].RegularPages) == 1)
 }
 
+func TestQuietBuild(t *testing.T) {
+	assert := require.New(t)
+
+	dir, err := createSimpleTestSite(t)
+	assert.NoError(err)
+
+	defer func() {
+		os.RemoveAll(dir)
+	}()
+
+	hugoCmd := newCommandsBuilder().addAll().build()
+	cmd := hugoCmd.getCommand()
+	cmd.SetArgs([]string{"-s=" + dir, "--quiet"})
+	_, err = cmd.ExecuteC()
+	assert.NoError(err)
+
+	c := hugoCmd.c
+	assert.NotNil(c)
+
+	var b bytes.Buffer
+	c.printProcessingStats(&b)
+	assert.Empty(b.String())
+
+	c.h.quiet = false
+	c.printProcessingStats(&b)
+	assert.Contains(b.String(), "Pages")
+	assert.Contains(b.String(), "Static files")
+}
+
 func TestCommandsPersistentFlags(t *testing.T) {
 	assert := require.New(t)
 
@@ -58,6 +88,7 @@ func TestCommandsPersistentFlags(t *testing.T) {
 		"--config=myconfig.toml",
 		"--contentDir=mycontent",
 		"--disableKinds=page,home",
+		"--renderSegments=blog,docs",
 		"--layoutDir=mylayouts",
 		"--theme=mytheme",
 		"--gc",
@@ -104,6 +135,7 @@ func TestCommandsPersistentFlags(t *testing.T) {
 		assert.Equal("https://example.com/b/", cfg.GetString("baseURL"))
 
 		assert.Equal([]string{"page", "home"}, cfg.Get("disableKinds"))
+		assert.Equal([]string{"blog", "docs"}, cfg.Get("renderSegments"))
 
 		assert.True(cfg.GetBool("uglyURLs"))
 		assert.True(cfg.GetBool("gc"))
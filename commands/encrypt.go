@@ -0,0 +1,117 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/spf13/cobra"
+	jww "github.com/spf13/jwalterweatherman"
+)
+
+var _ cmder = (*encryptCmd)(nil)
+
+type encryptCmd struct {
+	key     string
+	decrypt bool
+
+	*baseCmd
+}
+
+func newEncryptCmd() *encryptCmd {
+	cc := &encryptCmd{}
+
+	cc.baseCmd = newBaseCmd(&cobra.Command{
+		Use:   "encrypt [path ...]",
+		Short: "Encrypt content files",
+		Long: `Encrypt content files in place, front matter included.
+
+Encrypted content files are only built if the same key is set in
+contentEncryptionKey, e.g. with the HUGO_CONTENTENCRYPTIONKEY environment
+variable or the --contentEncryptionKey flag. Otherwise they are skipped.`,
+		RunE: cc.encrypt,
+	})
+
+	cc.cmd.Flags().StringVar(&cc.key, "key", "", "the key to use, default is $HUGO_CONTENTENCRYPTIONKEY")
+	cc.cmd.Flags().BoolVar(&cc.decrypt, "decrypt", false, "decrypt the files instead")
+
+	return cc
+}
+
+func (cc *encryptCmd) encrypt(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return newUserError("path needs to be provided")
+	}
+
+	key := cc.key
+	if key == "" {
+		key = os.Getenv("HUGO_CONTENTENCRYPTIONKEY")
+	}
+	if key == "" {
+		return newUserError("key needs to be provided with --key or HUGO_CONTENTENCRYPTIONKEY")
+	}
+
+	for _, filename := range args {
+		if err := cc.encryptFile(key, filename); err != nil {
+			return fmt.Errorf("%s: %s", filename, err)
+		}
+	}
+
+	return nil
+}
+
+func (cc *encryptCmd) encryptFile(key, filename string) error {
+	fi, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	encrypted := helpers.IsEncryptedContent(b)
+
+	if cc.decrypt {
+		if !encrypted {
+			return errors.New("file is not encrypted")
+		}
+		b, err = helpers.DecryptContent(key, b)
+	} else {
+		if encrypted {
+			return errors.New("file is already encrypted")
+		}
+		b, err = helpers.EncryptContent(key, b)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(filename, b, fi.Mode()); err != nil {
+		return err
+	}
+
+	if cc.decrypt {
+		jww.FEEDBACK.Println("Decrypted", filename)
+	} else {
+		jww.FEEDBACK.Println("Encrypted", filename)
+	}
+
+	return nil
+}
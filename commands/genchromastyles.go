@@ -39,7 +39,7 @@ func createGenChromaStyles() *genChromaStyles {
 		baseCmd: newBaseCmd(&cobra.Command{
 			Use:   "chromastyles",
 			Short: "Generate CSS stylesheet for the Chroma code highlighter",
-			Long: `Generate CSS stylesheet for the Chroma code highlighter for a given style. This stylesheet is needed if pygmentsUseClasses is enabled in config.
+			Long: `Generate CSS stylesheet for the Chroma code highlighter for a given style. This stylesheet is needed if pygmentsUseClasses is enabled or markup.highlight.noClasses is disabled in config.
 
 See https://help.farbox.com/pygments.html for preview of available styles`,
 		}),
@@ -17,6 +17,7 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os/signal"
 	"sort"
@@ -187,6 +188,10 @@ func initializeFlags(cmd *cobra.Command, cfg config.Provider) {
 		"noChmod",
 		"templateMetrics",
 		"templateMetricsHints",
+		"printPathWarnings",
+		"printUnusedTemplates",
+		"printMemoryUsage",
+		"contentEncryptionKey",
 
 		// Moved from vars.
 		"baseURL",
@@ -197,12 +202,14 @@ func initializeFlags(cmd *cobra.Command, cfg config.Provider) {
 		"debug",
 		"destination",
 		"disableKinds",
+		"environment",
 		"gc",
 		"layoutDir",
 		"logFile",
 		"i18n-warnings",
 		"quiet",
 		"renderToMemory",
+		"renderSegments",
 		"source",
 		"theme",
 		"themesDir",
@@ -314,7 +321,20 @@ func (c *commandeer) fullBuild() error {
 		s.ProcessingStats.Static = langCount[s.Language.Lang]
 	}
 
-	if c.h.gc {
+	// --dryRun implies --gc, but nothing is removed.
+	if c.h.gcDryRun {
+		files, err := c.hugo.GCDryRun()
+		if err != nil {
+			return err
+		}
+		for _, filename := range files {
+			c.Logger.FEEDBACK.Println("Would remove", filename)
+		}
+		for _, s := range c.hugo.Sites {
+			s.ProcessingStats.GCDryRun = true
+			s.ProcessingStats.WouldClean = uint64(len(files))
+		}
+	} else if c.h.gc {
 		count, err := c.hugo.GC()
 		if err != nil {
 			return err
@@ -336,12 +356,7 @@ func (c *commandeer) build() error {
 		return err
 	}
 
-	// TODO(bep) Feedback?
-	if !c.h.quiet {
-		fmt.Println()
-		c.hugo.PrintProcessingStats(os.Stdout)
-		fmt.Println()
-	}
+	c.printProcessingStats(os.Stdout)
 
 	if c.h.buildWatch {
 		watchDirs, err := c.getDirList()
@@ -370,16 +385,22 @@ func (c *commandeer) serverBuild() error {
 		return err
 	}
 
-	// TODO(bep) Feedback?
-	if !c.h.quiet {
-		fmt.Println()
-		c.hugo.PrintProcessingStats(os.Stdout)
-		fmt.Println()
-	}
+	c.printProcessingStats(os.Stdout)
 
 	return nil
 }
 
+// printProcessingStats prints the build stats table to w. Nothing is
+// printed in quiet mode; errors and warnings are reported by the logger.
+func (c *commandeer) printProcessingStats(w io.Writer) {
+	if c.h.quiet {
+		return
+	}
+	fmt.Fprintln(w)
+	c.hugo.PrintProcessingStats(w)
+	fmt.Fprintln(w)
+}
+
 func (c *commandeer) copyStatic() (map[string]uint64, error) {
 	return c.doWithPublishDirs(c.copyStaticTo)
 }
@@ -0,0 +1,76 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// The files written by --profile, in the current working directory.
+var profileFilenames = map[string]string{
+	"cpu":   "cpu.pprof",
+	"mem":   "mem.pprof",
+	"trace": "trace.out",
+}
+
+// startProfile starts the given profile, one of cpu, mem or trace, writing
+// to filename. The returned func stops the profiling and closes the file.
+func startProfile(mode, filename string) (func() error, error) {
+	if _, found := profileFilenames[mode]; !found {
+		return nil, fmt.Errorf("invalid profile %q, must be one of cpu, mem or trace", mode)
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var stop func() error
+
+	switch mode {
+	case "cpu":
+		err = pprof.StartCPUProfile(f)
+		stop = func() error {
+			pprof.StopCPUProfile()
+			return f.Close()
+		}
+	case "mem":
+		stop = func() error {
+			// Get up-to-date statistics.
+			runtime.GC()
+			if err := pprof.WriteHeapProfile(f); err != nil {
+				f.Close()
+				return err
+			}
+			return f.Close()
+		}
+	case "trace":
+		err = trace.Start(f)
+		stop = func() error {
+			trace.Stop()
+			return f.Close()
+		}
+	}
+
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return stop, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartProfile(t *testing.T) {
+	assert := require.New(t)
+
+	dir, err := ioutil.TempDir("", "hugo-profile")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	for mode, name := range profileFilenames {
+		filename := filepath.Join(dir, name)
+
+		stop, err := startProfile(mode, filename)
+		assert.NoError(err, mode)
+
+		// Do some work to profile.
+		var s []string
+		for i := 0; i < 10000; i++ {
+			s = append(s, strings.Repeat("a", i%100))
+		}
+		assert.Len(s, 10000)
+
+		assert.NoError(stop(), mode)
+
+		fi, err := os.Stat(filename)
+		assert.NoError(err, mode)
+		assert.True(fi.Size() > 0, mode)
+	}
+
+	_, err = startProfile("block", filepath.Join(dir, "block.pprof"))
+	assert.Error(err)
+	assert.Contains(err.Error(), `invalid profile "block"`)
+}
@@ -42,3 +42,37 @@ func ToLower(m map[string]interface{}) {
 
 	}
 }
+
+// MergeDeep merges src into dst recursively, modifying dst.
+// Values in src take precedence, but maps found in both dst and src are
+// merged instead of replaced. All other values, including slices, are replaced.
+// Any nested map[interface{}]interface{} will be converted to map[string]interface{}.
+func MergeDeep(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcMap, srcIsMap := AsStringMap(v)
+		if !srcIsMap {
+			dst[k] = v
+			continue
+		}
+
+		dstMap, dstIsMap := AsStringMap(dst[k])
+		if !dstIsMap {
+			dstMap = make(map[string]interface{})
+		}
+
+		MergeDeep(dstMap, srcMap)
+		dst[k] = dstMap
+	}
+}
+
+// AsStringMap returns v as a map[string]interface{} if v is a map
+// with either string or interface{} keys.
+func AsStringMap(v interface{}) (map[string]interface{}, bool) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		return vv, true
+	case map[interface{}]interface{}:
+		return cast.ToStringMap(vv), true
+	}
+	return nil, false
+}
@@ -70,3 +70,50 @@ func TestToLower(t *testing.T) {
 		}
 	}
 }
+
+func TestMergeDeep(t *testing.T) {
+
+	tests := []struct {
+		dst      map[string]interface{}
+		src      map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			map[string]interface{}{"a": 1, "b": 2},
+			map[string]interface{}{"b": 3, "c": 4},
+			map[string]interface{}{"a": 1, "b": 3, "c": 4},
+		},
+		{
+			// Maps are merged.
+			map[string]interface{}{
+				"a": map[string]interface{}{"b": 1, "c": map[string]interface{}{"d": 2, "e": 3}},
+			},
+			map[string]interface{}{
+				"a": map[interface{}]interface{}{"c": map[string]interface{}{"e": 4}},
+			},
+			map[string]interface{}{
+				"a": map[string]interface{}{"b": 1, "c": map[string]interface{}{"d": 2, "e": 4}},
+			},
+		},
+		{
+			// Slices are replaced.
+			map[string]interface{}{"a": []interface{}{1, 2, 3}},
+			map[string]interface{}{"a": []interface{}{4}},
+			map[string]interface{}{"a": []interface{}{4}},
+		},
+		{
+			// Scalar vs map conflicts: the value in src wins.
+			map[string]interface{}{"a": "scalar", "b": map[string]interface{}{"c": 1}},
+			map[string]interface{}{"a": map[string]interface{}{"c": 1}, "b": "scalar"},
+			map[string]interface{}{"a": map[string]interface{}{"c": 1}, "b": "scalar"},
+		},
+	}
+
+	for i, test := range tests {
+		// MergeDeep modifies dst.
+		MergeDeep(test.dst, test.src)
+		if !reflect.DeepEqual(test.expected, test.dst) {
+			t.Errorf("[%d] Expected\n%#v, got\n%#v\n", i, test.expected, test.dst)
+		}
+	}
+}
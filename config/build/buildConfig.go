@@ -0,0 +1,43 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"github.com/gohugoio/hugo/config"
+	"github.com/mitchellh/mapstructure"
+)
+
+const buildConfigKey = "build"
+
+// Config controls which auxiliary files, i.e. files not rendered from a
+// page or a template, Hugo writes to the publish directory.
+type Config struct {
+	// Do not write the redirect from /page/1/ to the first page of a
+	// paginated list.
+	NoPaginatorAliases bool
+
+	// Do not write the redirect between the root and the default content
+	// language in multilingual sites, e.g. from / to /en/ when
+	// defaultContentLanguageInSubdir is set.
+	NoLanguageRedirect bool
+}
+
+// DecodeConfig creates a build configuration from the build section in cfg.
+func DecodeConfig(cfg config.Provider) (c Config, err error) {
+	m := cfg.GetStringMap(buildConfigKey)
+
+	err = mapstructure.WeakDecode(m, &c)
+
+	return
+}
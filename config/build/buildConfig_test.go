@@ -0,0 +1,53 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"testing"
+
+	"github.com/gohugoio/hugo/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeConfigFromTOML(t *testing.T) {
+	assert := require.New(t)
+
+	tomlConfig := `
+
+someOtherValue = "foo"
+
+[build]
+noPaginatorAliases = true
+noLanguageRedirect = true
+`
+	cfg, err := config.FromConfigString(tomlConfig, "toml")
+	assert.NoError(err)
+
+	c, err := DecodeConfig(cfg)
+	assert.NoError(err)
+
+	assert.True(c.NoPaginatorAliases)
+	assert.True(c.NoLanguageRedirect)
+}
+
+func TestDecodeConfigDefault(t *testing.T) {
+	assert := require.New(t)
+
+	c, err := DecodeConfig(viper.New())
+	assert.NoError(err)
+
+	assert.False(c.NoPaginatorAliases)
+	assert.False(c.NoLanguageRedirect)
+}
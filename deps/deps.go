@@ -28,6 +28,9 @@ type Deps struct {
 	// Used to log errors that may repeat itself many times.
 	DistinctErrorLog *helpers.DistinctLogger
 
+	// Tallies the warnings logged during a build.
+	Warnings *helpers.WarningCounter
+
 	// The templates to use. This will usually implement the full tpl.TemplateHandler.
 	Tmpl tpl.TemplateFinder `json:"-"`
 
@@ -67,8 +70,14 @@ type Deps struct {
 
 	Metrics metrics.Provider
 
+	// Tracks the templates executed if printUnusedTemplates is enabled.
+	TemplateUsage *tpl.TemplateUsage
+
 	// Timeout is configurable in site config.
 	Timeout time.Duration
+
+	// Whether we are in running (server) mode.
+	Running bool
 }
 
 // ResourceProvider is used to create and refresh, and clone resources needed.
@@ -130,6 +139,10 @@ func New(cfg DepsCfg) (*Deps, error) {
 		fs = hugofs.NewDefault(cfg.Language)
 	}
 
+	if cfg.RenderToMemory {
+		fs = hugofs.WithMemDestination(fs)
+	}
+
 	ps, err := helpers.NewPathSpec(fs, cfg.Language)
 
 	if err != nil {
@@ -159,6 +172,7 @@ func New(cfg DepsCfg) (*Deps, error) {
 		Fs:                  fs,
 		Log:                 logger,
 		DistinctErrorLog:    distinctErrorLogger,
+		Warnings:            helpers.NewWarningCounter(),
 		templateProvider:    cfg.TemplateProvider,
 		translationProvider: cfg.TranslationProvider,
 		WithTemplate:        cfg.WithTemplate,
@@ -169,12 +183,17 @@ func New(cfg DepsCfg) (*Deps, error) {
 		Cfg:                 cfg.Language,
 		Language:            cfg.Language,
 		Timeout:             time.Duration(timeoutms) * time.Millisecond,
+		Running:             cfg.Running,
 	}
 
 	if cfg.Cfg.GetBool("templateMetrics") {
 		d.Metrics = metrics.NewProvider(cfg.Cfg.GetBool("templateMetricsHints"))
 	}
 
+	if cfg.Cfg.GetBool("printUnusedTemplates") {
+		d.TemplateUsage = tpl.NewTemplateUsage()
+	}
+
 	return d, nil
 }
 
@@ -243,4 +262,8 @@ type DepsCfg struct {
 
 	// Whether we are in running (server) mode
 	Running bool
+
+	// Whether to write the rendered site to an in-memory file system instead
+	// of Fs.Destination. The output can be read back from Deps.Fs.Destination.
+	RenderToMemory bool
 }
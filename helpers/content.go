@@ -462,6 +462,9 @@ type RenderingContext struct {
 	Config       *BlackFriday
 	RenderTOC    bool
 	Cfg          config.Provider
+
+	// Optional templates to render links and images in Markdown.
+	RenderHooks *RenderHooks
 }
 
 // RenderBytes renders a []byte.
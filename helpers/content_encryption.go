@@ -0,0 +1,105 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+)
+
+// encryptedContentPrefix marks a content file encrypted with EncryptContent.
+var encryptedContentPrefix = []byte("HUGO-ENCRYPTED-V1\n")
+
+// ErrWrongContentKey is returned by DecryptContent if the content could not be
+// decrypted with the given key.
+var ErrWrongContentKey = errors.New("wrong key or corrupted content")
+
+// IsEncryptedContent reports whether b is content encrypted with
+// EncryptContent.
+func IsEncryptedContent(b []byte) bool {
+	return bytes.HasPrefix(b, encryptedContentPrefix)
+}
+
+// EncryptContent encrypts the given content, front matter included, with
+// AES-256-GCM using a key derived from the given key string. The result is
+// a base64 encoded text file.
+func EncryptContent(key string, content []byte) ([]byte, error) {
+	gcm, err := newContentCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, content, nil)
+
+	b := make([]byte, len(encryptedContentPrefix)+base64.StdEncoding.EncodedLen(len(sealed))+1)
+	n := copy(b, encryptedContentPrefix)
+	base64.StdEncoding.Encode(b[n:], sealed)
+	b[len(b)-1] = '\n'
+
+	return b, nil
+}
+
+// DecryptContent decrypts content encrypted with EncryptContent.
+func DecryptContent(key string, b []byte) ([]byte, error) {
+	if !IsEncryptedContent(b) {
+		return nil, errors.New("content is not encrypted")
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(b[len(encryptedContentPrefix):])))
+	if err != nil {
+		return nil, ErrWrongContentKey
+	}
+
+	gcm, err := newContentCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, ErrWrongContentKey
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	content, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongContentKey
+	}
+
+	return content, nil
+}
+
+func newContentCipher(key string) (cipher.AEAD, error) {
+	if key == "" {
+		return nil, errors.New("no key provided")
+	}
+
+	k := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(k[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
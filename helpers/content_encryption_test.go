@@ -0,0 +1,53 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptContent(t *testing.T) {
+	assert := require.New(t)
+
+	content := []byte("---\ntitle: Secret\n---\nThe content.")
+
+	b, err := EncryptContent("mykey", content)
+	assert.NoError(err)
+	assert.True(IsEncryptedContent(b))
+	assert.NotContains(string(b), "Secret")
+
+	decrypted, err := DecryptContent("mykey", b)
+	assert.NoError(err)
+	assert.Equal(content, decrypted)
+
+	// A random nonce is used for every encryption.
+	b2, err := EncryptContent("mykey", content)
+	assert.NoError(err)
+	assert.NotEqual(b, b2)
+
+	_, err = DecryptContent("otherkey", b)
+	assert.Equal(ErrWrongContentKey, err)
+
+	_, err = DecryptContent("", b)
+	assert.Error(err)
+
+	_, err = DecryptContent("mykey", content)
+	assert.Error(err)
+	assert.False(IsEncryptedContent(content))
+
+	_, err = EncryptContent("", content)
+	assert.Error(err)
+}
@@ -0,0 +1,222 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// RenderHooks holds the renderers used to render links, images and code
+// blocks in Markdown, typically the render-link.html, render-image.html and
+// render-codeblock.html templates. A nil renderer means the default rendering.
+type RenderHooks struct {
+	Link  LinkRenderer
+	Image LinkRenderer
+
+	// CodeBlock returns the renderer for code blocks in the given language,
+	// which may be empty, or nil if there is none.
+	CodeBlock func(lang string) CodeBlockRenderer
+}
+
+// LinkRenderer renders a Markdown link or image.
+type LinkRenderer interface {
+	RenderLink(w io.Writer, ctx LinkContext) error
+}
+
+// LinkContext is the data passed to a LinkRenderer.
+type LinkContext struct {
+	// The page being rendered, if any.
+	Page interface{}
+
+	// The URL of the link or image.
+	Destination string
+
+	// The optional title attribute.
+	Title string
+
+	// The rendered link text, or the alt text for images.
+	Text template.HTML
+
+	// Text without any markup.
+	PlainText string
+}
+
+func (r *HugoHTMLRenderer) renderLinkHook(out *bytes.Buffer, renderer LinkRenderer, link, title, content []byte) bool {
+	ctx := LinkContext{
+		Destination: string(link),
+		Title:       string(title),
+		Text:        template.HTML(content),
+		PlainText:   StripHTML(string(content)),
+	}
+
+	marker := out.Len()
+	if err := renderer.RenderLink(out, ctx); err != nil {
+		out.Truncate(marker)
+		DistinctErrorLog.Printf("Failed to render link %q in %q: %s", link, r.DocumentName, err)
+		return false
+	}
+
+	return true
+}
+
+// Link renders a link, using the link render hook if set.
+func (r *HugoHTMLRenderer) Link(out *bytes.Buffer, link []byte, title []byte, content []byte) {
+	if r.RenderHooks != nil && r.RenderHooks.Link != nil {
+		if r.renderLinkHook(out, r.RenderHooks.Link, link, title, content) {
+			return
+		}
+	}
+	r.Renderer.Link(out, link, title, content)
+}
+
+// Image renders an image, using the image render hook if set.
+func (r *HugoHTMLRenderer) Image(out *bytes.Buffer, link []byte, title []byte, alt []byte) {
+	if r.RenderHooks != nil && r.RenderHooks.Image != nil {
+		if r.renderLinkHook(out, r.RenderHooks.Image, link, title, alt) {
+			return
+		}
+	}
+	r.Renderer.Image(out, link, title, alt)
+}
+
+// CodeBlockRenderer renders a fenced code block in Markdown.
+type CodeBlockRenderer interface {
+	RenderCodeBlock(w io.Writer, ctx CodeBlockContext) error
+}
+
+// CodeBlockContext is the data passed to a CodeBlockRenderer.
+type CodeBlockContext struct {
+	// The page being rendered, if any.
+	Page interface{}
+
+	// The language of the code block, e.g. "go". May be empty.
+	Lang string
+
+	// The code in the code block, as written. It is not escaped or
+	// highlighted, so diagram sources, e.g. mermaid, are passed through as is.
+	Code string
+
+	// The attributes set after the language, e.g. linenos and hl_lines in
+	// ```go {linenos=true hl_lines="2-3"}
+	Attributes map[string]string
+}
+
+// BlockCode renders a fenced code block, using the code block render hook if
+// set. If not, Pygments is used if it is setup to handle code fences.
+func (r *HugoHTMLRenderer) BlockCode(out *bytes.Buffer, text []byte, info string) {
+	lang, attrs := parseCodeBlockInfo(info)
+
+	var renderer CodeBlockRenderer
+	if r.RenderHooks != nil && r.RenderHooks.CodeBlock != nil {
+		renderer = r.RenderHooks.CodeBlock(lang)
+	}
+
+	if renderer != nil {
+		ctx := CodeBlockContext{
+			Lang:       lang,
+			Code:       strings.TrimRight(string(text), "\n\r"),
+			Attributes: attrs,
+		}
+
+		marker := out.Len()
+		err := renderer.RenderCodeBlock(out, ctx)
+		if err == nil {
+			return
+		}
+		out.Truncate(marker)
+		DistinctErrorLog.Printf("Failed to render code block in %q: %s", r.DocumentName, err)
+	}
+
+	if r.Cfg.GetBool("pygmentsCodeFences") && (lang != "" || r.Cfg.GetBool("pygmentsCodeFencesGuessSyntax")) {
+		opts := r.Cfg.GetString("pygmentsOptions")
+		str := strings.Trim(string(text), "\n\r")
+		highlighted, _ := r.cs.Highlight(str, lang, opts)
+		out.WriteString(highlighted)
+	} else {
+		r.Renderer.BlockCode(out, text, info)
+	}
+}
+
+// parseCodeBlockInfo splits the info string of a fenced code block, e.g.
+// "go {linenos=true hl_lines=\"2-3\"}", into the language and its attributes.
+func parseCodeBlockInfo(info string) (string, map[string]string) {
+	info = strings.TrimSpace(info)
+
+	lang := info
+	rest := ""
+	if i := strings.IndexFunc(info, unicode.IsSpace); i != -1 {
+		lang, rest = info[:i], info[i:]
+	}
+	if strings.HasPrefix(lang, "{") {
+		// The attributes come first, e.g. {linenos=true}.
+		lang, rest = "", info
+	}
+
+	attrs := make(map[string]string)
+
+	rest = strings.TrimSpace(rest)
+	rest = strings.TrimPrefix(rest, "{")
+	rest = strings.TrimSuffix(rest, "}")
+
+	for _, field := range splitCodeBlockAttributes(rest) {
+		kv := strings.SplitN(field, "=", 2)
+		key := strings.TrimSpace(kv[0])
+		if key == "" {
+			continue
+		}
+		value := ""
+		if len(kv) == 2 {
+			value = strings.Trim(strings.TrimSpace(kv[1]), `"'`)
+		}
+		attrs[key] = value
+	}
+
+	return lang, attrs
+}
+
+// splitCodeBlockAttributes splits s on commas and spaces outside of quotes.
+func splitCodeBlockAttributes(s string) []string {
+	var (
+		fields []string
+		field  strings.Builder
+		quote  rune
+	)
+
+	for _, r := range s {
+		switch {
+		case r == quote:
+			quote = 0
+		case quote != 0:
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ',' || unicode.IsSpace(r):
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+			continue
+		}
+		field.WriteRune(r)
+	}
+
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+
+	return fields
+}
@@ -0,0 +1,42 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCodeBlockInfo(t *testing.T) {
+	t.Parallel()
+
+	for i, test := range []struct {
+		info          string
+		expectedLang  string
+		expectedAttrs map[string]string
+	}{
+		{"", "", map[string]string{}},
+		{"go", "go", map[string]string{}},
+		{"go {linenos=true}", "go", map[string]string{"linenos": "true"}},
+		{"go {linenos=table, hl_lines=\"2-3 5\"}", "go", map[string]string{"linenos": "table", "hl_lines": "2-3 5"}},
+		{"go linenos=true hl_lines='1'", "go", map[string]string{"linenos": "true", "hl_lines": "1"}},
+		{"{title=\"My code\"}", "", map[string]string{"title": "My code"}},
+		{"bash {copy}", "bash", map[string]string{"copy": ""}},
+	} {
+		lang, attrs := parseCodeBlockInfo(test.info)
+		require.Equal(t, test.expectedLang, lang, "[%d] %s", i, test.info)
+		require.Equal(t, test.expectedAttrs, attrs, "[%d] %s", i, test.info)
+	}
+}
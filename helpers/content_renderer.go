@@ -30,19 +30,6 @@ type HugoHTMLRenderer struct {
 	blackfriday.Renderer
 }
 
-// BlockCode renders a given text as a block of code.
-// Pygments is used if it is setup to handle code fences.
-func (r *HugoHTMLRenderer) BlockCode(out *bytes.Buffer, text []byte, lang string) {
-	if r.Cfg.GetBool("pygmentsCodeFences") && (lang != "" || r.Cfg.GetBool("pygmentsCodeFencesGuessSyntax")) {
-		opts := r.Cfg.GetString("pygmentsOptions")
-		str := strings.Trim(string(text), "\n\r")
-		highlighted, _ := r.cs.Highlight(str, lang, opts)
-		out.WriteString(highlighted)
-	} else {
-		r.Renderer.BlockCode(out, text, lang)
-	}
-}
-
 // ListItem adds task list support to the Blackfriday renderer.
 func (r *HugoHTMLRenderer) ListItem(out *bytes.Buffer, text []byte, flags int) {
 	if !r.Config.TaskLists {
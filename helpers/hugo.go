@@ -18,9 +18,32 @@ import (
 	"strings"
 
 	"github.com/gohugoio/hugo/compare"
+	"github.com/gohugoio/hugo/config"
 	"github.com/spf13/cast"
 )
 
+const (
+	// EnvironmentDevelopment is the environment used by the Hugo server
+	// unless set explicitly.
+	EnvironmentDevelopment = "development"
+
+	// EnvironmentProduction is the environment used by regular builds
+	// unless set explicitly.
+	EnvironmentProduction = "production"
+)
+
+// BuildEnvironment returns the configured build environment, which defaults
+// to "development" when running the server and "production" otherwise.
+func BuildEnvironment(cfg config.Provider, running bool) string {
+	if env := cfg.GetString("environment"); env != "" {
+		return env
+	}
+	if running {
+		return EnvironmentDevelopment
+	}
+	return EnvironmentProduction
+}
+
 // HugoVersion represents the Hugo build version.
 type HugoVersion struct {
 	// Major and minor version.
@@ -117,8 +117,13 @@ func ishex(c rune) bool {
 // UnicodeSanitize sanitizes string to be used in Hugo URL's, allowing only
 // a predefined set of special Unicode characters.
 // If RemovePathAccents configuration flag is enabled, Uniccode accents
-// are also removed.
+// are also removed. If TransliteratePaths is enabled, letters are converted
+// to their closest ASCII equivalent, e.g. "naïve" => "naive".
 func (p *PathSpec) UnicodeSanitize(s string) string {
+	if p.TransliteratePaths {
+		s = transliterate(s)
+	}
+
 	source := []rune(s)
 	target := make([]rune, 0, len(source))
 
@@ -49,6 +49,8 @@ func TestMakePath(t *testing.T) {
 		{"трям/трям", "трям/трям", true},
 		{"은행", "은행", true},
 		{"Банковский кассир", "Банковскии-кассир", true},
+		{"crème-brûlée/café", "creme-brulee/cafe", true},
+		{"crème-brûlée/café", "crème-brûlée/café", false},
 		// Issue #1488
 		{"संस्कृत", "संस्कृत", false},
 		{"a%C3%B1ame", "a%C3%B1ame", false},         // Issue #1292
@@ -72,6 +74,42 @@ func TestMakePath(t *testing.T) {
 	}
 }
 
+func TestMakePathTransliterate(t *testing.T) {
+	v := newTestCfg()
+	v.Set("transliteratePaths", true)
+
+	l := langs.NewDefaultLanguage(v)
+	p, err := NewPathSpec(hugofs.NewMem(v), l)
+	require.NoError(t, err)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"naïve café", "naive-cafe"},
+		{"Crème Brûlée", "creme-brulee"},
+		{"Straße/Ærø", "strasse/aero"},
+		{"Łódź", "lodz"},
+		{"Банковский кассир", "bankovskiy-kassir"},
+		{"Жизнь/щи", "zhizn/shchi"},
+		{"Київ", "kiyiv"},
+		{"은행", "은행"},
+		{"a%C3%B1ame", "a%c3%b1ame"},
+	}
+
+	for _, test := range tests {
+		output := p.MakePathSanitized(test.input)
+		if output != test.expected {
+			t.Errorf("Expected %#v, got %#v\n", test.expected, output)
+		}
+	}
+
+	v.Set("disablePathToLower", true)
+	p, err = NewPathSpec(hugofs.NewMem(v), l)
+	require.NoError(t, err)
+	require.Equal(t, "Zhizn-Kiyiv", p.MakePathSanitized("Жизнь Київ"))
+}
+
 func TestMakePathSanitized(t *testing.T) {
 	v := viper.New()
 	v.Set("contentDir", "content")
@@ -27,6 +27,7 @@ func TestNewPathSpecFromConfig(t *testing.T) {
 	l := langs.NewLanguage("no", v)
 	v.Set("disablePathToLower", true)
 	v.Set("removePathAccents", true)
+	v.Set("transliteratePaths", true)
 	v.Set("uglyURLs", true)
 	v.Set("canonifyURLs", true)
 	v.Set("paginatePath", "side")
@@ -43,6 +44,7 @@ func TestNewPathSpecFromConfig(t *testing.T) {
 	require.True(t, p.CanonifyURLs)
 	require.True(t, p.DisablePathToLower)
 	require.True(t, p.RemovePathAccents)
+	require.True(t, p.TransliteratePaths)
 	require.True(t, p.UglyURLs)
 	require.Equal(t, "no", p.Language.Lang)
 	require.Equal(t, "side", p.PaginatePath)
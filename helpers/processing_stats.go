@@ -33,6 +33,10 @@ type ProcessingStats struct {
 	Aliases         uint64
 	Sitemaps        uint64
 	Cleaned         uint64
+	WouldClean      uint64
+
+	// Set when the garbage collection is a dry run, see WouldClean.
+	GCDryRun bool
 }
 
 type processingStatsTitleVal struct {
@@ -41,7 +45,7 @@ type processingStatsTitleVal struct {
 }
 
 func (s *ProcessingStats) toVals() []processingStatsTitleVal {
-	return []processingStatsTitleVal{
+	vals := []processingStatsTitleVal{
 		{"Pages", s.Pages},
 		{"Paginator pages", s.PaginatorPages},
 		{"Non-page files", s.Files},
@@ -51,6 +55,12 @@ func (s *ProcessingStats) toVals() []processingStatsTitleVal {
 		{"Sitemaps", s.Sitemaps},
 		{"Cleaned", s.Cleaned},
 	}
+
+	if s.GCDryRun {
+		vals = append(vals, processingStatsTitleVal{"Would clean", s.WouldClean})
+	}
+
+	return vals
 }
 
 func NewProcessingStats(name string) *ProcessingStats {
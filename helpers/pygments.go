@@ -231,6 +231,12 @@ func init() {
 	pygmentsKeywords["startinline"] = true
 }
 
+// IsPygmentsOption reports whether key is a valid highlighting option,
+// e.g. linenos or hl_lines.
+func IsPygmentsOption(key string) bool {
+	return pygmentsKeywords[strings.ToLower(key)]
+}
+
 func parseOptions(defaults map[string]string, in string) (map[string]string, error) {
 	in = strings.Trim(in, " ")
 	opts := make(map[string]string)
@@ -294,6 +300,29 @@ func parseDefaultPygmentsOpts(cfg config.Provider) (map[string]string, error) {
 
 	}
 
+	// The markup.highlight section takes precedence over the older
+	// pygments settings.
+	if cfg.IsSet("markup.highlight.style") {
+		options["style"] = cfg.GetString("markup.highlight.style")
+	}
+
+	if cfg.IsSet("markup.highlight.noClasses") {
+		options["noclasses"] = strconv.FormatBool(cfg.GetBool("markup.highlight.noClasses"))
+	}
+
+	if cfg.IsSet("markup.highlight.lineNos") {
+		// Either a bool or one of "table" and "inline".
+		lineNos := strings.ToLower(cfg.GetString("markup.highlight.lineNos"))
+		switch lineNos {
+		case "true":
+			options["linenos"] = "table"
+		case "false", "":
+			delete(options, "linenos")
+		default:
+			options["linenos"] = lineNos
+		}
+	}
+
 	if _, ok := options["encoding"]; !ok {
 		options["encoding"] = "utf8"
 	}
@@ -309,7 +338,7 @@ func (cs *ContentSpec) chromaFormatterFromOptions(pygmentsOpts map[string]string
 	}
 
 	lineNumbers := pygmentsOpts["linenos"]
-	if lineNumbers != "" {
+	if lineNumbers != "" && lineNumbers != "false" {
 		options = append(options, html.WithLineNumbers())
 		if lineNumbers != "inline" {
 			options = append(options, html.LineNumbersInTable())
@@ -223,6 +223,79 @@ func TestChromaHTMLFormatterFromOptions(t *testing.T) {
 	}
 }
 
+func TestChromaHTMLFormatterFromMarkupHighlightConfig(t *testing.T) {
+	assert := require.New(t)
+
+	for i, this := range []struct {
+		in            string
+		highlightConf map[string]interface{}
+		assert        func(opts map[string]string, c chromaInfo)
+	}{
+		{"", map[string]interface{}{"style": "dracula", "noClasses": false}, func(opts map[string]string, c chromaInfo) {
+			assert.Equal("dracula", opts["style"])
+			assert.True(c.classes)
+			// From pygmentsOptions.
+			assert.True(c.lineNumbersInTable)
+		}},
+		{"", map[string]interface{}{"noClasses": true, "lineNos": true}, func(opts map[string]string, c chromaInfo) {
+			assert.Equal("monokai", opts["style"])
+			assert.False(c.classes)
+			assert.True(c.lineNumbers)
+			assert.True(c.lineNumbersInTable)
+		}},
+		{"", map[string]interface{}{"lineNos": "inline"}, func(opts map[string]string, c chromaInfo) {
+			assert.True(c.lineNumbers)
+			assert.False(c.lineNumbersInTable)
+		}},
+		{"", map[string]interface{}{"lineNos": false}, func(opts map[string]string, c chromaInfo) {
+			assert.False(c.lineNumbers)
+		}},
+		// Options passed to highlight win.
+		{"linenos=inline,noclasses=true", map[string]interface{}{"lineNos": false, "noClasses": false}, func(opts map[string]string, c chromaInfo) {
+			assert.False(c.classes)
+			assert.True(c.lineNumbers)
+		}},
+	} {
+		v := viper.New()
+		v.Set("pygmentsStyle", "monokai")
+		v.Set("pygmentsUseClasses", false)
+		v.Set("pygmentsOptions", "linenos=table")
+		v.Set("markup", map[string]interface{}{"highlight": this.highlightConf})
+
+		spec, err := NewContentSpec(v)
+		assert.NoError(err)
+
+		opts, err := spec.parsePygmentsOpts(this.in)
+		if err != nil {
+			t.Fatalf("[%d] parsePygmentsOpts failed: %s", i, err)
+		}
+
+		chromaFormatter, err := spec.chromaFormatterFromOptions(opts)
+		if err != nil {
+			t.Fatalf("[%d] chromaFormatterFromOptions failed: %s", i, err)
+		}
+
+		this.assert(opts, formatterChromaInfo(chromaFormatter.(*html.Formatter)))
+	}
+}
+
+func TestChromaHTMLHighlightWithClassesFromMarkupConfig(t *testing.T) {
+	assert := require.New(t)
+
+	v := viper.New()
+	v.Set("markup", map[string]interface{}{
+		"highlight": map[string]interface{}{"noClasses": false},
+	})
+	spec, err := NewContentSpec(v)
+	assert.NoError(err)
+
+	result, err := spec.Highlight(`echo "Hello"`, "bash", "")
+	assert.NoError(err)
+
+	assert.Contains(result, `<span class="nb">echo</span>`)
+	assert.NotContains(result, `style=`)
+}
+
 func TestHlLinesToRanges(t *testing.T) {
 	var zero [][2]int
 
@@ -0,0 +1,78 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// transliterations maps lower case letters that do not decompose into an
+// ASCII letter and accents to their ASCII romanization. The Cyrillic letters
+// follow a simplified variant of the common Russian, Ukrainian and Serbian
+// romanization schemes.
+var transliterations = map[rune]string{
+	// Latin
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d",
+	'ł': "l", 'þ': "th", 'ı': "i", 'ħ': "h",
+
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ў': "u",
+	'ђ': "dj", 'ј': "j", 'љ': "lj", 'њ': "nj", 'ћ': "c", 'џ': "dz",
+}
+
+func init() {
+	// Add the upper case variants, e.g. Ж => Zh.
+	for r, s := range transliterations {
+		upper := unicode.ToUpper(r)
+		if upper == r {
+			continue
+		}
+		if _, found := transliterations[upper]; found {
+			continue
+		}
+		if s != "" {
+			s = strings.ToUpper(s[:1]) + s[1:]
+		}
+		transliterations[upper] = s
+	}
+}
+
+// transliterate converts the letters in s to their closest ASCII equivalent,
+// e.g. "naïve" => "naive" and "Жизнь" => "Zhizn". Runes it does not know are
+// left as is.
+func transliterate(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if t, found := transliterations[r]; found {
+			b.WriteString(t)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	// Remove any remaining accents, see https://blog.golang.org/normalization
+	t := transform.Chain(norm.NFD, transform.RemoveFunc(isMn), norm.NFC)
+	result, _, _ := transform.String(t, b.String())
+
+	return result
+}
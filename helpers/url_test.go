@@ -50,6 +50,30 @@ func TestURLize(t *testing.T) {
 	}
 }
 
+func TestURLizeDisablePathToLower(t *testing.T) {
+	v := newTestCfg()
+	v.Set("disablePathToLower", true)
+	l := langs.NewDefaultLanguage(v)
+	p, _ := NewPathSpec(hugofs.NewMem(v), l)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"  Foo Bar  ", "Foo-Bar"},
+		{"Foo.Bar/fOO_bAr-Foo", "Foo.Bar/fOO_bAr-Foo"},
+		{"CamelCase/MixedCase.HTML", "CamelCase/MixedCase.HTML"},
+		{"Трям/трям", "%D0%A2%D1%80%D1%8F%D0%BC/%D1%82%D1%80%D1%8F%D0%BC"},
+	}
+
+	for _, test := range tests {
+		output := p.URLize(test.input)
+		if output != test.expected {
+			t.Errorf("Expected %#v, got %#v\n", test.expected, output)
+		}
+	}
+}
+
 func TestAbsURL(t *testing.T) {
 	for _, defaultInSubDir := range []bool{true, false} {
 		for _, addLanguage := range []bool{true, false} {
@@ -0,0 +1,120 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The warning kinds tallied by the WarningCounter.
+const (
+	WarningBrokenRef          = "broken ref"
+	WarningMissingTranslation = "missing translation"
+	WarningDataOverride       = "data override"
+	WarningUnknownAnchor      = "unknown anchor"
+	WarningDuplicatePath      = "duplicate target path"
+)
+
+var warningKindPlurals = map[string]string{
+	WarningBrokenRef:          "broken refs",
+	WarningMissingTranslation: "missing translations",
+	WarningDataOverride:       "data overrides",
+	WarningUnknownAnchor:      "unknown anchors",
+	WarningDuplicatePath:      "duplicate target paths",
+}
+
+// WarningCounter tallies build warnings by kind so a summary can be
+// printed when the build is done.
+type WarningCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// NewWarningCounter creates a new, empty WarningCounter.
+func NewWarningCounter() *WarningCounter {
+	return &WarningCounter{counts: make(map[string]int)}
+}
+
+// Incr increments the count for the given warning kind.
+func (c *WarningCounter) Incr(kind string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.counts[kind]++
+	c.mu.Unlock()
+}
+
+// Printf logs the message to the given logger and counts it as a warning
+// of the given kind.
+func (c *WarningCounter) Printf(logger LogPrinter, kind, format string, v ...interface{}) {
+	logger.Println(fmt.Sprintf(format, v...))
+	c.Incr(kind)
+}
+
+// Count returns the number of warnings of the given kind.
+func (c *WarningCounter) Count(kind string) int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[kind]
+}
+
+// Reset clears all counts, e.g. before a rebuild.
+func (c *WarningCounter) Reset() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.counts = make(map[string]int)
+	c.mu.Unlock()
+}
+
+// Summary returns a summary line of the warnings counted, sorted by kind,
+// e.g. "3 broken refs, 2 missing translations". It returns an empty string
+// if there are no warnings.
+func (c *WarningCounter) Summary() string {
+	if c == nil {
+		return ""
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	kinds := make([]string, 0, len(c.counts))
+	for kind := range c.counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	parts := make([]string, len(kinds))
+	for i, kind := range kinds {
+		count := c.counts[kind]
+		name := kind
+		if count != 1 {
+			if plural, found := warningKindPlurals[kind]; found {
+				name = plural
+			} else {
+				name += "s"
+			}
+		}
+		parts[i] = fmt.Sprintf("%d %s", count, name)
+	}
+
+	return strings.Join(parts, ", ")
+}
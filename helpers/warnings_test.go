@@ -0,0 +1,57 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarningCounter(t *testing.T) {
+	assert := require.New(t)
+
+	c := NewWarningCounter()
+	assert.Equal("", c.Summary())
+
+	var buf bytes.Buffer
+	logger := log.New(&buf, "", 0)
+
+	for i := 0; i < 3; i++ {
+		c.Incr(WarningBrokenRef)
+	}
+	c.Incr(WarningMissingTranslation)
+	c.Incr(WarningMissingTranslation)
+	c.Printf(logger, WarningDataOverride, "data %s overridden", "foo")
+	c.Incr("stale cache")
+	c.Incr("stale cache")
+
+	assert.Equal(3, c.Count(WarningBrokenRef))
+	assert.Equal(2, c.Count(WarningMissingTranslation))
+	assert.Equal(1, c.Count(WarningDataOverride))
+	assert.Equal(0, c.Count("unknown"))
+	assert.Equal("data foo overridden\n", buf.String())
+	assert.Equal("3 broken refs, 1 data override, 2 missing translations, 2 stale caches", c.Summary())
+
+	c.Reset()
+	assert.Equal(0, c.Count(WarningBrokenRef))
+	assert.Equal("", c.Summary())
+
+	var nilCounter *WarningCounter
+	nilCounter.Incr(WarningBrokenRef)
+	assert.Equal(0, nilCounter.Count(WarningBrokenRef))
+	assert.Equal("", nilCounter.Summary())
+}
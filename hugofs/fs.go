@@ -62,6 +62,14 @@ func NewFrom(fs afero.Fs, cfg config.Provider) *Fs {
 	return newFs(fs, cfg)
 }
 
+// WithMemDestination creates a copy of fs with a new MemMapFs as its
+// destination file system. The source file systems are kept as is.
+func WithMemDestination(fs *Fs) *Fs {
+	c := *fs
+	c.Destination = &afero.MemMapFs{}
+	return &c
+}
+
 func newFs(base afero.Fs, cfg config.Provider) *Fs {
 	return &Fs{
 		Source:      base,
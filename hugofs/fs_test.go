@@ -58,3 +58,15 @@ func TestWorkingDir(t *testing.T) {
 	assert.NotNil(t, f.WorkingDir)
 	assert.IsType(t, new(afero.BasePathFs), f.WorkingDir)
 }
+
+func TestWithMemDestination(t *testing.T) {
+	v := viper.New()
+	f := NewDefault(v)
+
+	m := WithMemDestination(f)
+
+	assert.IsType(t, new(afero.OsFs), m.Source)
+	assert.IsType(t, new(afero.MemMapFs), m.Destination)
+	// The original is left untouched.
+	assert.IsType(t, new(afero.OsFs), f.Destination)
+}
@@ -0,0 +1,108 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugofs
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+var (
+	_ afero.Fs = (*TrackingFs)(nil)
+)
+
+// TrackingFs is a filesystem that records the files written to it, and the
+// existing files looked up with Stat. This is used to find the published files
+// that are no longer part of the site after a build.
+type TrackingFs struct {
+	afero.Fs
+
+	mu      sync.RWMutex
+	touched map[string]bool
+}
+
+// NewTrackingFs creates a new TrackingFs backed by delegate.
+func NewTrackingFs(delegate afero.Fs) *TrackingFs {
+	return &TrackingFs{Fs: delegate, touched: make(map[string]bool)}
+}
+
+func (fs *TrackingFs) Create(name string) (afero.File, error) {
+	f, err := fs.Fs.Create(name)
+	if err == nil {
+		fs.track(name)
+	}
+	return f, err
+}
+
+func (fs *TrackingFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	f, err := fs.Fs.OpenFile(name, flag, perm)
+	if err == nil && isWrite(flag) {
+		fs.track(name)
+	}
+	return f, err
+}
+
+func (fs *TrackingFs) Stat(name string) (os.FileInfo, error) {
+	fi, err := fs.Fs.Stat(name)
+	if err == nil && !fi.IsDir() {
+		fs.track(name)
+	}
+	return fi, err
+}
+
+func (fs *TrackingFs) Name() string {
+	return "TrackingFs"
+}
+
+// IsTouched reports whether the file with the given name was written to or
+// looked up since the last Reset.
+func (fs *TrackingFs) IsTouched(name string) bool {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	return fs.touched[trackingKey(name)]
+}
+
+// Touched returns the names of all the recorded files, sorted.
+func (fs *TrackingFs) Touched() []string {
+	fs.mu.RLock()
+	names := make([]string, 0, len(fs.touched))
+	for name := range fs.touched {
+		names = append(names, name)
+	}
+	fs.mu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// Reset forgets all the recorded files.
+func (fs *TrackingFs) Reset() {
+	fs.mu.Lock()
+	fs.touched = make(map[string]bool)
+	fs.mu.Unlock()
+}
+
+func (fs *TrackingFs) track(name string) {
+	fs.mu.Lock()
+	fs.touched[trackingKey(name)] = true
+	fs.mu.Unlock()
+}
+
+func trackingKey(name string) string {
+	return strings.TrimPrefix(filepath.Clean(name), string(filepath.Separator))
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugofs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackingFs(t *testing.T) {
+	assert := require.New(t)
+
+	base := afero.NewMemMapFs()
+	afero.WriteFile(base, filepath.FromSlash("old/file.txt"), []byte("old"), 0755)
+	afero.WriteFile(base, "existing.txt", []byte("existing"), 0755)
+
+	fs := NewTrackingFs(base)
+
+	f, err := fs.Create(filepath.FromSlash("/a/b.txt"))
+	assert.NoError(err)
+	f.Close()
+
+	f, err = fs.OpenFile("c.txt", os.O_CREATE|os.O_WRONLY, 0755)
+	assert.NoError(err)
+	f.Close()
+
+	_, err = fs.Stat("existing.txt")
+	assert.NoError(err)
+
+	f, err = fs.Open(filepath.FromSlash("old/file.txt"))
+	assert.NoError(err)
+	f.Close()
+
+	assert.True(fs.IsTouched(filepath.FromSlash("a/b.txt")))
+	assert.True(fs.IsTouched(filepath.FromSlash("/a/b.txt")))
+	assert.True(fs.IsTouched("c.txt"))
+	assert.True(fs.IsTouched("existing.txt"))
+	// Reading a file does not count.
+	assert.False(fs.IsTouched(filepath.FromSlash("old/file.txt")))
+
+	assert.Equal([]string{filepath.FromSlash("a/b.txt"), "c.txt", "existing.txt"}, fs.Touched())
+
+	fs.Reset()
+	assert.False(fs.IsTouched("c.txt"))
+}
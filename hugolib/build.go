@@ -0,0 +1,69 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/helpers"
+)
+
+// BuildResult holds the outcome of a Build.
+type BuildResult struct {
+	// The sites built, one per language.
+	Sites *HugoSites
+
+	// The processing stats for each site, in the same order as Sites.Sites.
+	Stats []*helpers.ProcessingStats
+
+	// The number of errors logged during the build.
+	NumErrors int
+
+	// The files published by the build, relative to the publish dir and
+	// sorted by name. This includes the existing files the build looked up
+	// and left as is because they were unchanged, e.g. copied images.
+	Paths []string
+}
+
+// Build creates the sites from the given configuration and file systems and
+// does a full build. This is the entry point to use when embedding Hugo:
+//
+//	result, err := hugolib.Build(deps.DepsCfg{Fs: fs, Cfg: cfg, RenderToMemory: true}, hugolib.BuildCfg{})
+//
+// The returned error is only set if the build failed. Errors logged during
+// the build are counted in BuildResult.NumErrors.
+func Build(cfg deps.DepsCfg, buildCfg BuildCfg) (*BuildResult, error) {
+	h, err := NewHugoSites(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker := h.PathSpec.BaseFs.TrackPublished()
+
+	if err := h.Build(buildCfg); err != nil {
+		return nil, err
+	}
+
+	result := &BuildResult{
+		Sites:     h,
+		Stats:     make([]*helpers.ProcessingStats, len(h.Sites)),
+		NumErrors: h.NumLogErrors(),
+		Paths:     tracker.Touched(),
+	}
+
+	for i, s := range h.Sites {
+		result.Stats[i] = s.PathSpec.ProcessingStats
+	}
+
+	return result, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gohugoio/hugo/deps"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	cfg, fs := newTestCfg()
+	cfg.Set("baseURL", "http://example.com/")
+	cfg.Set("defaultContentLanguageInSubdir", false)
+	cfg.Set("disableKinds", []string{KindTaxonomy, KindTaxonomyTerm})
+
+	writeSource(t, fs, filepath.Join("content", "p1.md"), "---\ntitle: p1\n---\n")
+	writeSource(t, fs, filepath.Join("content", "p2.md"), "---\ntitle: p2\n---\n")
+	writeSource(t, fs, filepath.Join("layouts", "_default", "single.html"), "Single: {{ .Title }}")
+	writeSource(t, fs, filepath.Join("layouts", "index.html"), "Home")
+
+	result, err := Build(deps.DepsCfg{Fs: fs, Cfg: cfg}, BuildCfg{})
+	assert.NoError(err)
+
+	assert.Len(result.Sites.Sites, 1)
+	assert.Len(result.Stats, 1)
+	assert.Equal(0, result.NumErrors)
+
+	// The home page, its RSS feed and the two regular pages.
+	assert.Equal(uint64(4), result.Stats[0].Pages)
+
+	assert.Equal([]string{
+		"index.html",
+		"index.xml",
+		filepath.FromSlash("p1/index.html"),
+		filepath.FromSlash("p2/index.html"),
+		"sitemap.xml",
+	}, result.Paths)
+
+	single, err := result.Sites.ReadPublishedFile("p1/index.html")
+	assert.NoError(err)
+	assert.Equal("Single: p1", string(single))
+}
+
+func TestBuildVisitor(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	cfg, fs := newTestCfg()
+	cfg.Set("baseURL", "http://example.com/")
+	cfg.Set("defaultContentLanguageInSubdir", false)
+	cfg.Set("disableKinds", []string{KindTaxonomy, KindTaxonomyTerm, kindRSS, kindSitemap})
+
+	const numPages = 20
+
+	for i := 0; i < numPages; i++ {
+		writeSource(t, fs, filepath.Join("content", fmt.Sprintf("p%d.md", i)), fmt.Sprintf("---\ntitle: p%d\n---\n", i))
+	}
+	writeSource(t, fs, filepath.Join("layouts", "_default", "single.html"), "Single: {{ .Title }}")
+	writeSource(t, fs, filepath.Join("layouts", "index.html"), "Home")
+
+	var (
+		inFlight   int32
+		concurrent bool
+		visited    = make(map[string]string)
+	)
+
+	visitor := func(path string, content []byte) error {
+		if atomic.AddInt32(&inFlight, 1) > 1 {
+			concurrent = true
+		}
+		defer atomic.AddInt32(&inFlight, -1)
+		visited[path] = string(content)
+		return nil
+	}
+
+	result, err := Build(deps.DepsCfg{Fs: fs, Cfg: cfg}, BuildCfg{Visitor: visitor})
+	assert.NoError(err)
+
+	assert.False(concurrent)
+	assert.Len(visited, numPages+1)
+	assert.Equal(uint64(len(visited)), result.Stats[0].Pages)
+	assert.Equal("Home", visited["index.html"])
+	assert.Equal("Single: p7", visited[filepath.FromSlash("p7/index.html")])
+
+	_, err = Build(deps.DepsCfg{Fs: fs, Cfg: cfg}, BuildCfg{Visitor: func(path string, content []byte) error {
+		return errors.New("visitor failed")
+	}})
+	assert.Error(err)
+	assert.Contains(err.Error(), "visitor failed")
+}
@@ -16,15 +16,19 @@ package hugolib
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/gohugoio/hugo/hugolib/paths"
 
 	"io"
 	"strings"
 
+	"github.com/gohugoio/hugo/common/maps"
 	"github.com/gohugoio/hugo/langs"
 
 	"github.com/gohugoio/hugo/config"
+	"github.com/gohugoio/hugo/config/build"
 	"github.com/gohugoio/hugo/config/privacy"
 	"github.com/gohugoio/hugo/config/services"
 	"github.com/gohugoio/hugo/helpers"
@@ -40,6 +44,9 @@ type SiteConfig struct {
 
 	// Services contains config for services such as Google Analytics etc.
 	Services services.Config
+
+	// Build controls the auxiliary files written to the publish directory.
+	Build build.Config
 }
 
 func loadSiteConfig(cfg config.Provider) (scfg SiteConfig, err error) {
@@ -53,8 +60,14 @@ func loadSiteConfig(cfg config.Provider) (scfg SiteConfig, err error) {
 		return
 	}
 
+	buildConfig, err := build.DecodeConfig(cfg)
+	if err != nil {
+		return
+	}
+
 	scfg.Privacy = privacyConfig
 	scfg.Services = servicesConfig
+	scfg.Build = buildConfig
 
 	return
 }
@@ -72,6 +85,15 @@ type ConfigSourceDescriptor struct {
 
 	// The project's working dir. Is used to look for additional theme config.
 	WorkingDir string
+
+	// The absolute path to the directory holding environment specific config,
+	// i.e. /my/project/config. Config files found in its "_default" and
+	// Environment sub directories are merged on top of the main config.
+	AbsConfigDir string
+
+	// The build environment, e.g. "production". If set, this overrides any
+	// "environment" config setting.
+	Environment string
 }
 
 func (d ConfigSourceDescriptor) configFilenames() []string {
@@ -135,6 +157,21 @@ func LoadConfig(d ConfigSourceDescriptor, doWithConfig ...func(cfg config.Provid
 
 	}
 
+	if d.Environment != "" {
+		v.Set("environment", d.Environment)
+	}
+
+	dirConfigFiles, err := loadConfigFromConfigDir(d, v)
+	if err != nil {
+		return v, configFiles, err
+	}
+
+	if len(dirConfigFiles) > 0 {
+		configFiles = append(configFiles, dirConfigFiles...)
+		// A config directory is a valid replacement for the main config file.
+		configFileErr = nil
+	}
+
 	if err := loadDefaultSettingsFor(v); err != nil {
 		return v, configFiles, err
 	}
@@ -151,6 +188,8 @@ func LoadConfig(d ConfigSourceDescriptor, doWithConfig ...func(cfg config.Provid
 		}
 	}
 
+	loadConfigFromEnvironment(v, os.Environ())
+
 	// We create languages based on the settings, so we need to make sure that
 	// all configuration is loaded/set before doing that.
 	for _, d := range doWithConfig {
@@ -167,6 +206,131 @@ func LoadConfig(d ConfigSourceDescriptor, doWithConfig ...func(cfg config.Provid
 
 }
 
+// loadConfigFromConfigDir merges the config files in the "_default" and
+// environment sub directories of d.AbsConfigDir into v1, in that order.
+// A file named config.toml is merged into the root, any other file into the
+// key matching its base name, e.g. params.toml into "params".
+// Maps are merged, all other values, including arrays, are replaced.
+func loadConfigFromConfigDir(d ConfigSourceDescriptor, v1 *viper.Viper) ([]string, error) {
+	if d.AbsConfigDir == "" {
+		return nil, nil
+	}
+
+	environment := helpers.BuildEnvironment(v1, false)
+
+	var configFiles []string
+
+	for _, dirname := range []string{"_default", environment} {
+		if dirname == "_default" && environment == "_default" {
+			continue
+		}
+
+		dir := filepath.Join(d.AbsConfigDir, dirname)
+		fis, err := afero.ReadDir(d.Fs, dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return configFiles, err
+		}
+
+		for _, fi := range fis {
+			if fi.IsDir() {
+				continue
+			}
+
+			ext := strings.TrimPrefix(filepath.Ext(fi.Name()), ".")
+			if !helpers.InStringArray(viper.SupportedExts, ext) {
+				continue
+			}
+
+			filename := filepath.Join(dir, fi.Name())
+			m, err := readConfigFileToMap(d.Fs, filename, ext)
+			if err != nil {
+				return configFiles, err
+			}
+
+			if key := strings.ToLower(strings.TrimSuffix(fi.Name(), filepath.Ext(fi.Name()))); key != "config" {
+				m = map[string]interface{}{key: m}
+			}
+
+			for k, v := range m {
+				mergeConfigKey(v1, k, v)
+			}
+
+			configFiles = append(configFiles, filename)
+		}
+	}
+
+	return configFiles, nil
+}
+
+// mergeConfigKey deep merges value into the config value for key.
+// Maps are merged, all other values are replaced.
+func mergeConfigKey(v1 *viper.Viper, key string, value interface{}) {
+	merged := map[string]interface{}{key: v1.Get(key)}
+	maps.MergeDeep(merged, map[string]interface{}{key: value})
+	v1.Set(key, merged[key])
+}
+
+// loadConfigFromEnvironment overlays config values from OS environment
+// variables prefixed with HUGO_. An underscore in the remaining name marks a
+// nested key, so HUGO_PARAMS_APIKEY sets params.apikey. Nesting is only
+// applied to map values, e.g. params. Other keys are handled by Viper.
+func loadConfigFromEnvironment(v1 *viper.Viper, environ []string) {
+	const prefix = "HUGO_"
+
+	for _, env := range environ {
+		if !strings.HasPrefix(env, prefix) {
+			continue
+		}
+
+		kv := strings.SplitN(strings.TrimPrefix(env, prefix), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		keys := strings.Split(strings.ToLower(kv[0]), "_")
+		if len(keys) < 2 || keys[0] == "" {
+			continue
+		}
+
+		root := keys[0]
+		if _, isMap := maps.AsStringMap(v1.Get(root)); !isMap && root != "params" {
+			continue
+		}
+
+		var value interface{} = kv[1]
+		for i := len(keys) - 1; i > 0; i-- {
+			if keys[i] == "" {
+				value = nil
+				break
+			}
+			value = map[string]interface{}{keys[i]: value}
+		}
+
+		if value != nil {
+			mergeConfigKey(v1, root, value)
+		}
+	}
+}
+
+func readConfigFileToMap(fs afero.Fs, filename, ext string) (map[string]interface{}, error) {
+	f, err := fs.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	v := viper.New()
+	v.SetConfigType(ext)
+	if err := v.ReadConfig(f); err != nil {
+		return nil, fmt.Errorf("Unable to parse config file (%s).\n (%s)\n", filename, err)
+	}
+
+	return v.AllSettings(), nil
+}
+
 func loadLanguageSettings(cfg config.Provider, oldLangs langs.Languages) error {
 
 	defaultLang := cfg.GetString("defaultContentLanguage")
@@ -425,9 +589,11 @@ func loadDefaultSettingsFor(v *viper.Viper) error {
 	v.SetDefault("uglyURLs", false)
 	v.SetDefault("verbose", false)
 	v.SetDefault("ignoreCache", false)
+	v.SetDefault("remoteDataCacheTTL", "")
 	v.SetDefault("canonifyURLs", false)
 	v.SetDefault("relativeURLs", false)
 	v.SetDefault("removePathAccents", false)
+	v.SetDefault("transliteratePaths", false)
 	v.SetDefault("titleCaseStyle", "AP")
 	v.SetDefault("taxonomies", map[string]string{"tag": "tags", "category": "categories"})
 	v.SetDefault("permalinks", make(PermalinkOverrides, 0))
@@ -440,6 +606,7 @@ func loadDefaultSettingsFor(v *viper.Viper) error {
 	v.SetDefault("disableLiveReload", false)
 	v.SetDefault("pluralizeListTitles", true)
 	v.SetDefault("preserveTaxonomyNames", false)
+	v.SetDefault("caseInsensitiveTaxonomyTerms", false)
 	v.SetDefault("forceSyncStatic", false)
 	v.SetDefault("footnoteAnchorPrefix", "")
 	v.SetDefault("footnoteReturnLinkContents", "")
@@ -447,6 +614,7 @@ func loadDefaultSettingsFor(v *viper.Viper) error {
 	v.SetDefault("paginate", 10)
 	v.SetDefault("paginatePath", "page")
 	v.SetDefault("summaryLength", 70)
+	v.SetDefault("keywordSources", []string{"keywords", "tags"})
 	v.SetDefault("blackfriday", c.BlackFriday)
 	v.SetDefault("rSSUri", "index.xml")
 	v.SetDefault("rssLimit", -1)
@@ -462,6 +630,12 @@ func loadDefaultSettingsFor(v *viper.Viper) error {
 	v.SetDefault("enableGitInfo", false)
 	v.SetDefault("ignoreFiles", make([]string, 0))
 	v.SetDefault("disableAliases", false)
+	v.SetDefault("strictGetPage", false)
+	v.SetDefault("printPathWarnings", false)
+	v.SetDefault("printUnusedTemplates", false)
+	v.SetDefault("printMemoryUsage", false)
+	v.SetDefault("contentEncryptionKey", "")
+	v.SetDefault("strictTargetPaths", false)
 	v.SetDefault("debug", false)
 	v.SetDefault("disableFastRender", false)
 	v.SetDefault("timeout", 10000) // 10 seconds
@@ -14,6 +14,7 @@
 package hugolib
 
 import (
+	"os"
 	"testing"
 
 	"github.com/spf13/afero"
@@ -71,6 +72,133 @@ func TestLoadMultiConfig(t *testing.T) {
 	assert.Equal("same", cfg.GetString("DontChange"))
 }
 
+func TestLoadConfigDir(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	mm := afero.NewMemMapFs()
+
+	writeToFs(t, mm, "config.toml", `
+baseURL = "https://example.org"
+paginatePath = "side"
+`)
+
+	writeToFs(t, mm, "config/_default/config.toml", `
+title = "Default Title"
+[taxonomies]
+tag = "tags"
+`)
+
+	writeToFs(t, mm, "config/_default/params.toml", `
+a = "a_default"
+b = "b_default"
+list = [1, 2, 3]
+[nested]
+c = "c_default"
+d = "d_default"
+`)
+
+	writeToFs(t, mm, "config/production/config.toml", `
+title = "Production Title"
+`)
+
+	writeToFs(t, mm, "config/production/params.yaml", `
+b: b_production
+list: [4]
+nested:
+  d: d_production
+`)
+
+	for _, env := range []string{"", "production"} {
+		cfg, configFiles, err := LoadConfig(ConfigSourceDescriptor{Fs: mm, Filename: "config.toml", AbsConfigDir: "config", Environment: env})
+		require.NoError(t, err)
+
+		assert.Len(configFiles, 5)
+		assert.Equal("https://example.org", cfg.GetString("baseURL"))
+		assert.Equal("side", cfg.GetString("paginatePath"))
+		assert.Equal("Production Title", cfg.GetString("title"))
+		assert.Equal("tags", cfg.GetString("taxonomies.tag"))
+		assert.Equal("a_default", cfg.GetString("params.a"))
+		assert.Equal("b_production", cfg.GetString("params.b"))
+		// Arrays replace.
+		assert.Equal([]interface{}{4}, cfg.Get("params.list"))
+		// Maps merge.
+		assert.Equal("c_default", cfg.GetString("params.nested.c"))
+		assert.Equal("d_production", cfg.GetString("params.nested.d"))
+	}
+
+	cfg, _, err := LoadConfig(ConfigSourceDescriptor{Fs: mm, Filename: "config.toml", AbsConfigDir: "config", Environment: "development"})
+	require.NoError(t, err)
+
+	assert.Equal("development", cfg.GetString("environment"))
+	assert.Equal("Default Title", cfg.GetString("title"))
+	assert.Equal("b_default", cfg.GetString("params.b"))
+	assert.Equal("d_default", cfg.GetString("params.nested.d"))
+
+	// The config directory alone is enough.
+	mm2 := afero.NewMemMapFs()
+	writeToFs(t, mm2, "config/_default/config.toml", `title = "Dir Only"`)
+
+	cfg, _, err = LoadConfig(ConfigSourceDescriptor{Fs: mm2, AbsConfigDir: "config"})
+	require.NoError(t, err)
+	assert.Equal("Dir Only", cfg.GetString("title"))
+}
+
+func TestLoadConfigFromEnvironment(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	mm := afero.NewMemMapFs()
+
+	writeToFs(t, mm, "config.toml", `
+baseURL = "https://example.org"
+[params]
+a = "a_config"
+[params.nested]
+b = "b_config"
+c = "c_config"
+[taxonomies]
+tag = "tags"
+`)
+
+	cfg, _, err := LoadConfig(ConfigSourceDescriptor{Fs: mm, Filename: "config.toml"})
+	require.NoError(t, err)
+
+	loadConfigFromEnvironment(cfg, []string{
+		"HUGO_PARAMS_APIKEY=secret",
+		"HUGO_PARAMS_NESTED_C=c_env",
+		"HUGO_TAXONOMIES_CATEGORY=categories",
+		"HUGO_PARAMS__INVALID=invalid",
+		"HUGO_BASEURL_FOO=ignored",
+		"NOT_HUGO_PARAMS_A=ignored",
+	})
+
+	assert.Equal("secret", cfg.GetString("params.apikey"))
+	assert.Equal("a_config", cfg.GetString("params.a"))
+	assert.Equal("b_config", cfg.GetString("params.nested.b"))
+	assert.Equal("c_env", cfg.GetString("params.nested.c"))
+	assert.Equal("tags", cfg.GetString("taxonomies.tag"))
+	assert.Equal("categories", cfg.GetString("taxonomies.category"))
+	assert.Equal("https://example.org", cfg.GetString("baseURL"))
+	assert.False(cfg.IsSet("params.invalid"))
+}
+
+func TestSiteParamsFromOSEnvironment(t *testing.T) {
+	// Not parallel, this test sets an OS environment variable.
+	const key = "HUGO_PARAMS_APIKEYFROMENV"
+	os.Setenv(key, "my-secret")
+	defer os.Unsetenv(key)
+
+	b := newTestSitesBuilder(t)
+	b.WithSimpleConfigFile()
+	b.WithTemplatesAdded("index.html", `API Key: {{ .Site.Params.apikeyfromenv }}|{{ .Param "apiKeyFromEnv" }}`)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "API Key: my-secret|my-secret")
+}
+
 func TestLoadConfigFromTheme(t *testing.T) {
 	t.Parallel()
 
@@ -387,3 +515,90 @@ privacyEnhanced = true
 	assert.True(b.H.Sites[0].Info.Config.Privacy.YouTube.PrivacyEnhanced)
 
 }
+
+func TestServicesConfig(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	tomlConfig := `
+
+someOtherValue = "foo"
+disqusShortname = "rootshort"
+
+[services]
+[services.googleAnalytics]
+id = "ga_id"
+[services.instagram]
+disableInlineCSS = true
+[services.twitter]
+disableInlineCSS = true
+`
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", tomlConfig)
+	b.WithTemplatesAdded("index.html", `
+GA: {{ .Site.Config.Services.GoogleAnalytics.ID }}|{{ .Site.GoogleAnalytics }}
+Disqus: {{ .Site.Config.Services.Disqus.Shortname }}
+Instagram: {{ .Site.Config.Services.Instagram.DisableInlineCSS }}
+`)
+	b.Build(BuildCfg{})
+
+	services := b.H.Sites[0].Info.Config.Services
+	assert.Equal("ga_id", services.GoogleAnalytics.ID)
+	assert.Equal("rootshort", services.Disqus.Shortname)
+	assert.True(services.Instagram.DisableInlineCSS)
+	assert.True(services.Twitter.DisableInlineCSS)
+
+	b.AssertFileContent("public/index.html",
+		"GA: ga_id|ga_id",
+		"Disqus: rootshort",
+		"Instagram: true",
+	)
+
+}
+
+func TestPrivacyConfigInternalTemplates(t *testing.T) {
+	t.Parallel()
+
+	tomlConfig := `
+baseURL = "https://example.org"
+googleAnalytics = "ga_id"
+
+[privacy]
+[privacy.googleAnalytics]
+anonymizeIP = true
+[privacy.youtube]
+privacyEnhanced = true
+[privacy.vimeo]
+disable = true
+[privacy.disqus]
+disable = true
+`
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", tomlConfig)
+	b.WithContent("page.md", `---
+title: Page
+---
+YouTube: {{< youtube ZJthWmvUzzc >}}
+Vimeo: {{< vimeo 146022717 >}}|
+`)
+	b.WithTemplatesAdded("_default/single.html", `{{ .Content }}
+{{ template "_internal/google_analytics.html" . }}
+Disqus: {{ template "_internal/disqus.html" . }}|
+`)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/page/index.html",
+		"//www.youtube-nocookie.com/embed/ZJthWmvUzzc",
+		"Vimeo: |",
+		"ga('set', 'anonymizeIp', true);",
+		"Disqus: |",
+	)
+
+	content := readDestination(t, b.Fs, "public/page/index.html")
+	require.NotContains(t, content, "www.youtube.com")
+	require.NotContains(t, content, "player.vimeo.com")
+
+}
@@ -219,3 +219,106 @@ func stringSliceContains(k string, values ...string) bool {
 	}
 	return false
 }
+
+func TestDisableKindsInSections(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "http://example.com/"
+
+[disableKindsInSections]
+blog = ["RSS"]
+news = ["taxonomy"]
+"docs/internal" = ["page"]
+`)
+
+	var content []string
+	for _, filename := range []string{"blog/b1.md", "blog/sub/_index.md", "blog/sub/b2.md", "docs/d1.md", "docs/internal/i1.md", "news/n1.md"} {
+		content = append(content, filename, `---
+title: Page
+tags: [hugo]
+---
+`)
+	}
+	b.WithContent(content...)
+
+	b.WithTemplates(
+		"_default/single.html", `Single`,
+		"_default/list.html", `List: {{ range .Pages }}{{ .RelPermalink }}|{{ end }}`,
+		"_default/rss.xml", `RSS`,
+	)
+
+	b.Build(BuildCfg{})
+
+	fs := b.H.Fs.Destination
+	assertExists := func(filename string, expected bool) {
+		exists, _ := afero.Exists(fs, filename)
+		assert.Equal(expected, exists, filename)
+	}
+
+	// RSS is suppressed in blog and its subsections, but present elsewhere.
+	assertExists("public/blog/index.html", true)
+	assertExists("public/blog/index.xml", false)
+	assertExists("public/blog/sub/index.xml", false)
+	assertExists("public/docs/index.xml", true)
+	assertExists("public/news/index.xml", true)
+	assertExists("public/index.xml", true)
+
+	assertExists("public/docs/d1/index.html", true)
+	assertExists("public/docs/internal/i1/index.html", false)
+
+	// The news pages are kept out of the taxonomies.
+	b.AssertFileContent("public/tags/hugo/index.html", "/blog/b1/|", "/blog/sub/b2/|", "/docs/d1/|")
+	assert.NotContains(readDestination(t, b.Fs, "public/tags/hugo/index.html"), "/news/n1/")
+}
+
+func TestDisableSectionKindsInSections(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "http://example.com/"
+
+[disableKindsInSections]
+Legacy = ["section"]
+archive = ["sitemap"]
+`)
+
+	b.WithContent(
+		"Legacy/_index.md", "---\ntitle: Legacy\n---\n",
+		"Legacy/l1.md", "---\ntitle: L1\n---\n",
+		"Legacy/sub/l2.md", "---\ntitle: L2\n---\n",
+		"archive/a1.md", "---\ntitle: A1\n---\n",
+		"blog/b1.md", "---\ntitle: B1\n---\n",
+	)
+
+	b.WithTemplates(
+		"_default/single.html", `Single`,
+		"_default/list.html", `List`,
+	)
+
+	b.Build(BuildCfg{})
+
+	assert.Nil(b.H.Sites[0].getPage(KindSection, "Legacy"))
+	assert.NotNil(b.H.Sites[0].getPage(KindSection, "blog"))
+
+	fs := b.H.Fs.Destination
+	for filename, expected := range map[string]bool{
+		"public/legacy/index.html":        false,
+		"public/legacy/l1/index.html":     true,
+		"public/legacy/sub/l2/index.html": true,
+		"public/blog/index.html":          true,
+	} {
+		exists, _ := afero.Exists(fs, filename)
+		assert.Equal(expected, exists, filename)
+	}
+
+	sitemap := readDestination(t, b.Fs, "public/sitemap.xml")
+	assert.Contains(sitemap, "http://example.com/blog/</loc>")
+	assert.Contains(sitemap, "http://example.com/legacy/l1/</loc>")
+	assert.NotContains(sitemap, "http://example.com/legacy/</loc>")
+	assert.NotContains(sitemap, "/archive/")
+}
@@ -14,6 +14,8 @@
 package hugolib
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -55,3 +57,157 @@ Disqus:
 	// Disqus
 	b.AssertFileContent("public/index.html", "\"disqus_shortname\" + '.disqus.com/embed.js';")
 }
+
+func TestEmbeddedTemplatesCanonicalURL(t *testing.T) {
+	t.Parallel()
+
+	single := []string{"_default/single.html", `
+{{ template "_internal/canonical.html" . }}
+{{ template "_internal/opengraph.html" . }}
+`}
+
+	b := newTestSitesBuilder(t)
+	b.WithSimpleConfigFile().WithTemplatesAdded(single...)
+	b.WithContent("sect/p1.md", `---
+title: P1
+---
+`, "sect/p2.md", `---
+title: P2
+canonicalURL: https://example.com/original/p2/
+---
+`, "sect/p3.md", `---
+title: P3
+canonicalURL: /sect/p1/
+---
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/sect/p1/index.html",
+		`<link rel="canonical" href="http://example.com/sect/p1/" />`,
+		`<meta property="og:url" content="http://example.com/sect/p1/" />`)
+	b.AssertFileContent("public/sect/p2/index.html",
+		`<link rel="canonical" href="https://example.com/original/p2/" />`,
+		`<meta property="og:url" content="https://example.com/original/p2/" />`)
+	b.AssertFileContent("public/sect/p3/index.html",
+		`<link rel="canonical" href="http://example.com/sect/p1/" />`)
+}
+
+func TestEmbeddedTemplatesImages(t *testing.T) {
+	t.Parallel()
+
+	single := []string{"_default/single.html", `
+{{ template "_internal/opengraph.html" . }}
+{{ template "_internal/twitter_cards.html" . }}
+`}
+
+	b := newTestSitesBuilder(t)
+	b.WithSimpleConfigFile().WithTemplatesAdded(single...)
+	b.WithContent("bundle/index.md", `---
+title: Bundle
+description: Bundle description
+---
+`, "bundle/a.jpg", "image", "bundle/b.jpg", "image",
+		"featured/index.md", `---
+title: Featured
+---
+`, "featured/a.jpg", "image", "featured/the-cover.jpg", "image",
+		"params/index.md", `---
+title: Params
+images: ["/images/p1.jpg", "/images/p2.jpg"]
+---
+`, "params/a.jpg", "image")
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/bundle/index.html",
+		`<meta property="og:title" content="Bundle" />`,
+		`<meta property="og:description" content="Bundle description" />`,
+		`<meta property="og:type" content="article" />`,
+		`<meta property="og:image" content="http://example.com/bundle/a.jpg" />`,
+		`<meta name="twitter:image" content="http://example.com/bundle/a.jpg"/>`)
+
+	b.AssertFileContent("public/featured/index.html",
+		`<meta property="og:image" content="http://example.com/featured/the-cover.jpg" />`,
+		`<meta name="twitter:image" content="http://example.com/featured/the-cover.jpg"/>`)
+
+	b.AssertFileContent("public/params/index.html",
+		`<meta property="og:image" content="http://example.com/images/p1.jpg" />`,
+		`<meta property="og:image" content="http://example.com/images/p2.jpg" />`,
+		`<meta name="twitter:image" content="http://example.com/images/p1.jpg"/>`)
+}
+
+func TestEmbeddedTemplatesJSONLD(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	templates := []string{
+		"_default/single.html", `{{ template "_internal/jsonld.html" . }}`,
+		"index.html", `{{ template "_internal/jsonld.html" . }}`,
+	}
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+title = "My Site"
+languageCode = "en-us"
+
+[author]
+name = "Site Author"
+
+[params]
+description = "Site description"
+`).WithTemplatesAdded(templates...)
+	b.WithContent("post/p1.md", `---
+title: "Post \"1\" & more"
+description: Post description
+date: 2018-06-01T10:20:00Z
+lastmod: 2018-06-03T08:00:00Z
+author: Jane Doe
+tags: [a, b]
+images: [/images/p1.jpg]
+---
+Some content.
+`, "post/p2.md", `---
+title: Post 2
+publishDate: 2018-06-05
+---
+`)
+
+	b.Build(BuildCfg{})
+
+	parse := func(filename string) map[string]interface{} {
+		content := readDestination(t, b.Fs, filename)
+		start := strings.Index(content, "{")
+		end := strings.LastIndex(content, "}")
+		assert.True(start > 0 && end > start, content)
+		var m map[string]interface{}
+		assert.NoError(json.Unmarshal([]byte(content[start:end+1]), &m), content)
+		return m
+	}
+
+	p1 := parse("public/post/p1/index.html")
+	assert.Equal("BlogPosting", p1["@type"])
+	assert.Equal(`Post "1" & more`, p1["headline"])
+	assert.Equal("Post description", p1["description"])
+	assert.Equal("http://example.com/post/p1/", p1["url"])
+	assert.Equal("2018-06-01T10:20:00+00:00", p1["datePublished"])
+	assert.Equal("2018-06-03T08:00:00+00:00", p1["dateModified"])
+	assert.Equal(map[string]interface{}{"@type": "Person", "name": "Jane Doe"}, p1["author"])
+	assert.Equal("http://example.com/images/p1.jpg", p1["image"])
+	assert.Equal("a, b", p1["keywords"])
+	assert.Equal(float64(2), p1["wordCount"])
+	assert.Equal("en-us", p1["inLanguage"])
+
+	p2 := parse("public/post/p2/index.html")
+	assert.Equal("Post 2", p2["headline"])
+	assert.Equal("Site description", p2["description"])
+	assert.Equal("2018-06-05T00:00:00+00:00", p2["datePublished"])
+	assert.Equal(map[string]interface{}{"@type": "Person", "name": "Site Author"}, p2["author"])
+
+	home := parse("public/index.html")
+	assert.Equal("WebSite", home["@type"])
+	assert.Equal("My Site", home["name"])
+	assert.Nil(home["datePublished"])
+}
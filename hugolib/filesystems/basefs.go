@@ -52,6 +52,11 @@ type BaseFs struct {
 	// This usually maps to /my-project/public.
 	PublishFs afero.Fs
 
+	// PublishTracker records the files published in the last full build. It
+	// is only set when running with --gc or building with hugolib.Build, and
+	// wraps PublishFs.
+	PublishTracker *hugofs.TrackingFs
+
 	themeFs afero.Fs
 
 	// TODO(bep) improve the "theme interaction"
@@ -125,6 +130,13 @@ func (s SourceFilesystems) ContentStaticAssetFs(lang string) afero.Fs {
 
 }
 
+// AssetsContentFs returns a read-only composite filesystem of the assets and
+// content filesystems used to look up resources with resources.Get. Assets
+// take precedence over content if the same filename lives in both.
+func (s SourceFilesystems) AssetsContentFs() afero.Fs {
+	return afero.NewReadOnlyFs(afero.NewCopyOnWriteFs(s.Content.Fs, s.Assets.Fs))
+}
+
 // StaticFs returns the static filesystem for the given language.
 // This can be a composite filesystem.
 func (s SourceFilesystems) StaticFs(lang string) afero.Fs {
@@ -262,6 +274,16 @@ func WithBaseFs(b *BaseFs) func(*BaseFs) error {
 	}
 }
 
+// TrackPublished wraps PublishFs in a PublishTracker, if not already done,
+// and returns the tracker.
+func (b *BaseFs) TrackPublished() *hugofs.TrackingFs {
+	if b.PublishTracker == nil {
+		b.PublishTracker = hugofs.NewTrackingFs(b.PublishFs)
+		b.PublishFs = b.PublishTracker
+	}
+	return b.PublishTracker
+}
+
 func newRealBase(base afero.Fs) afero.Fs {
 	return hugofs.NewBasePathRealFilenameFs(base.(*afero.BasePathFs))
 
@@ -273,7 +295,12 @@ func NewBase(p *paths.Paths, options ...func(*BaseFs) error) (*BaseFs, error) {
 
 	publishFs := afero.NewBasePathFs(fs.Destination, p.AbsPublishDir)
 
-	contentFs, absContentDirs, err := createContentFs(fs.Source, p.WorkingDir, p.DefaultContentLanguage, p.Languages)
+	mounts, err := decodeMounts(p.Cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	contentFs, absContentDirs, err := createContentFs(fs.Source, p.WorkingDir, p.DefaultContentLanguage, p.Languages, mountsFor(mounts, "content"))
 	if err != nil {
 		return nil, err
 	}
@@ -294,6 +321,10 @@ func NewBase(p *paths.Paths, options ...func(*BaseFs) error) (*BaseFs, error) {
 		PublishFs: publishFs,
 	}
 
+	if p.Cfg.GetBool("gc") {
+		b.TrackPublished()
+	}
+
 	for _, opt := range options {
 		if err := opt(b); err != nil {
 			return nil, err
@@ -301,6 +332,7 @@ func NewBase(p *paths.Paths, options ...func(*BaseFs) error) (*BaseFs, error) {
 	}
 
 	builder := newSourceFilesystemsBuilder(p, b)
+	builder.mounts = mounts
 	sourceFilesystems, err := builder.Build()
 	if err != nil {
 		return nil, err
@@ -325,6 +357,7 @@ type sourceFilesystemsBuilder struct {
 	themeFs      afero.Fs
 	hasTheme     bool
 	absThemeDirs []string
+	mounts       []Mount
 }
 
 func newSourceFilesystemsBuilder(p *paths.Paths, b *BaseFs) *sourceFilesystemsBuilder {
@@ -430,6 +463,16 @@ func (b *sourceFilesystemsBuilder) createFs(
 		s.Dirnames = []string{absDir}
 	}
 
+	if mountDirs := b.mountDirs(themeFolder); len(mountDirs) > 0 {
+		// The project wins, then the mounts in the order given.
+		s.Dirnames = append(s.Dirnames, mountDirs...)
+		mfs, err := createOverlayFs(b.p.Fs.Source, reverseStrings(s.Dirnames))
+		if err != nil {
+			return nil, err
+		}
+		fs = mfs
+	}
+
 	if b.hasTheme {
 		themeFolderFs := newRealBase(afero.NewBasePathFs(b.themeFs, themeFolder))
 		if fs == nil {
@@ -477,6 +520,11 @@ func (b *sourceFilesystemsBuilder) createRootMappingFs(dirKey, themeFolder strin
 		fromTo = []string{projectVirtualFolder, to}
 	}
 
+	for i, to := range b.mountDirs(themeFolder) {
+		s.Dirnames = append(s.Dirnames, to)
+		fromTo = append(fromTo, fmt.Sprintf("%s_mount%d", projectVirtualFolder, i), to)
+	}
+
 	for _, theme := range b.p.AllThemes {
 		to := b.p.AbsPathify(filepath.Join(b.p.ThemesDir, theme.Name, themeFolder))
 		if b.existsInSource(to) {
@@ -522,6 +570,9 @@ func (b *sourceFilesystemsBuilder) createStaticFs() error {
 				continue
 			}
 
+			// The last static dir wins, so the mounts go first.
+			s.Dirnames = reverseStrings(b.mountDirsForLang("static", l.Lang))
+
 			for _, dir := range staticDirs {
 				absDir := b.p.AbsPathify(dir)
 				if !b.existsInSource(absDir) {
@@ -559,6 +610,8 @@ func (b *sourceFilesystemsBuilder) createStaticFs() error {
 		return nil
 	}
 
+	s.Dirnames = reverseStrings(b.mountDirs("static"))
+
 	for _, dir := range staticDirs {
 		absDir := b.p.AbsPathify(dir)
 		if !b.existsInSource(absDir) {
@@ -607,7 +660,8 @@ func getStringOrStringSlice(cfg config.Provider, key string, id int) []string {
 func createContentFs(fs afero.Fs,
 	workingDir,
 	defaultContentLanguage string,
-	languages langs.Languages) (afero.Fs, []string, error) {
+	languages langs.Languages,
+	mounts []Mount) (afero.Fs, []string, error) {
 
 	var contentLanguages langs.Languages
 	var contentDirSeen = make(map[string]bool)
@@ -634,6 +688,34 @@ func createContentFs(fs afero.Fs,
 
 	}
 
+	// Mounted content comes after the project's content dirs, so the project wins.
+	for _, m := range mounts {
+		lang := m.Lang
+		if lang == "" {
+			lang = defaultContentLanguage
+		}
+
+		var language *langs.Language
+		for _, l := range languages {
+			if l.Lang == lang {
+				language = l
+				break
+			}
+		}
+		if language == nil {
+			return nil, nil, fmt.Errorf("content mount %q: language %q not found", m.Source, lang)
+		}
+
+		if contentDirSeen[m.Source] {
+			continue
+		}
+		contentDirSeen[m.Source] = true
+
+		mounted := *language
+		mounted.ContentDir = m.Source
+		contentLanguages = append(contentLanguages, &mounted)
+	}
+
 	var absContentDirs []string
 
 	fs, err := createContentOverlayFs(fs, workingDir, contentLanguages, languageSet, &absContentDirs)
@@ -732,6 +814,14 @@ func createOverlayFs(source afero.Fs, absPaths []string) (afero.Fs, error) {
 	return afero.NewCopyOnWriteFs(base, overlay), nil
 }
 
+func reverseStrings(in []string) []string {
+	out := make([]string, len(in))
+	for i, v := range in {
+		out[len(in)-1-i] = v
+	}
+	return out
+}
+
 func removeDuplicatesKeepRight(in []string) []string {
 	seen := make(map[string]bool)
 	var out []string
@@ -117,6 +117,94 @@ theme = ["atheme"]
 
 }
 
+func TestNewBaseFsMounts(t *testing.T) {
+	assert := require.New(t)
+	v := createConfig()
+	workingDir := filepath.FromSlash("/my/work")
+	v.Set("workingDir", workingDir)
+	v.Set("defaultContentLanguage", "en")
+
+	v.Set("module", map[string]interface{}{
+		"mounts": []map[string]interface{}{
+			{"source": "shared/content", "target": "content"},
+			{"source": "other/content", "target": "content"},
+			{"source": "shared/static", "target": "static"},
+			{"source": "shared/layouts", "target": "layouts"},
+			{"source": "shared/data", "target": "data"},
+			{"source": "does/not/exist", "target": "assets"},
+		},
+	})
+
+	fs := hugofs.NewMem(v)
+
+	write := func(filename, content string) {
+		afero.WriteFile(fs.Source, filepath.Join(workingDir, filepath.FromSlash(filename)), []byte(content), 0755)
+	}
+
+	write("mycontent/project.md", "project")
+	write("mycontent/both.md", "project")
+	write("shared/content/shared.md", "shared")
+	write("shared/content/both.md", "shared")
+	write("shared/content/mounts.md", "shared")
+	write("other/content/other.md", "other")
+	write("other/content/mounts.md", "other")
+	write("mystatic/project.css", "project")
+	write("mystatic/both.css", "project")
+	write("shared/static/shared.css", "shared")
+	write("shared/static/both.css", "shared")
+	write("mylayouts/index.html", "project")
+	write("shared/layouts/index.html", "shared")
+	write("shared/layouts/_default/single.html", "shared")
+	write("shared/data/shared.toml", "shared = true")
+
+	p, err := paths.New(fs, v)
+	assert.NoError(err)
+	bfs, err := NewBase(p)
+	assert.NoError(err)
+
+	checkFileCount(bfs.Content.Fs, "", assert, 5)
+	checkFileCount(bfs.Static[""].Fs, "", assert, 3)
+	checkFileCount(bfs.Layouts.Fs, "", assert, 2)
+	checkFileCount(bfs.Data.Fs, "", assert, 1)
+	assert.Equal(hugofs.NoOpFs, bfs.Assets.Fs)
+
+	root, err := bfs.Content.Fs.Open("")
+	assert.NoError(err)
+	fis, err := root.Readdir(-1)
+	assert.NoError(err)
+	found := make(map[string]string)
+	for _, fi := range fis {
+		lfi := fi.(*hugofs.LanguageFileInfo)
+		found[lfi.RealName()] = lfi.Filename()
+	}
+	assert.Equal(map[string]string{
+		"project.md": filepath.FromSlash("/my/work/mycontent/project.md"),
+		"both.md":    filepath.FromSlash("/my/work/mycontent/both.md"),
+		"shared.md":  filepath.FromSlash("/my/work/shared/content/shared.md"),
+		"mounts.md":  filepath.FromSlash("/my/work/shared/content/mounts.md"),
+		"other.md":   filepath.FromSlash("/my/work/other/content/other.md"),
+	}, found)
+
+	assert.True(bfs.IsContent(filepath.Join(workingDir, "other", "content", "other.md")))
+	assert.Equal("other.md", bfs.RelContentDir(filepath.Join(workingDir, "other", "content", "other.md")))
+
+	checkFileContent(bfs.Static[""].Fs, "both.css", assert, "project")
+	checkFileContent(bfs.Static[""].Fs, "shared.css", assert, "shared")
+	checkFileContent(bfs.Layouts.Fs, "index.html", assert, "project")
+	checkFileContent(bfs.Layouts.Fs, filepath.Join("_default", "single.html"), assert, "shared")
+
+	assert.Equal([]string{filepath.FromSlash("/my/work/mylayouts"), filepath.FromSlash("/my/work/shared/layouts")}, bfs.Layouts.Dirnames)
+	assert.True(bfs.IsData(filepath.Join(workingDir, "shared", "data", "shared.toml")))
+
+	v.Set("module", map[string]interface{}{
+		"mounts": []map[string]interface{}{
+			{"source": "shared/content", "target": "contents"},
+		},
+	})
+	_, err = NewBase(p)
+	assert.Error(err)
+}
+
 func createConfig() *viper.Viper {
 	v := viper.New()
 	v.Set("contentDir", "mycontent")
@@ -151,6 +239,29 @@ func TestNewBaseFsEmpty(t *testing.T) {
 	assert.NotNil(bfs.Static)
 }
 
+func TestNewBaseFsPublishTracker(t *testing.T) {
+	assert := require.New(t)
+	v := createConfig()
+	fs := hugofs.NewMem(v)
+	p, err := paths.New(fs, v)
+	assert.NoError(err)
+
+	// Nothing to track for.
+	bfs, err := NewBase(p)
+	assert.NoError(err)
+	assert.Nil(bfs.PublishTracker)
+
+	tracker := bfs.TrackPublished()
+	assert.True(bfs.PublishFs == tracker)
+	assert.True(bfs.TrackPublished() == tracker)
+
+	v.Set("gc", true)
+	bfs, err = NewBase(p)
+	assert.NoError(err)
+	assert.NotNil(bfs.PublishTracker)
+	assert.True(bfs.PublishFs == bfs.PublishTracker)
+}
+
 func TestRealDirs(t *testing.T) {
 	assert := require.New(t)
 	v := createConfig()
@@ -248,3 +359,9 @@ func setConfigAndWriteSomeFilesTo(fs afero.Fs, v *viper.Viper, key, val string,
 		afero.WriteFile(fs, filepath.Join(workingDir, val, fmt.Sprintf("file%d.txt", i+1)), []byte(fmt.Sprintf("content:%s:%d", key, i+1)), 0755)
 	}
 }
+
+func checkFileContent(fs afero.Fs, filename string, assert *require.Assertions, expected string) {
+	b, err := afero.ReadFile(fs, filename)
+	assert.NoError(err)
+	assert.Equal(expected, string(b))
+}
@@ -0,0 +1,112 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystems
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gohugoio/hugo/config"
+	"github.com/mitchellh/mapstructure"
+)
+
+const mountsConfigKey = "module.mounts"
+
+// The component folders a directory can be mounted into.
+var mountTargets = map[string]bool{
+	"content":    true,
+	"static":     true,
+	"assets":     true,
+	"layouts":    true,
+	"archetypes": true,
+	"data":       true,
+	"i18n":       true,
+}
+
+// Mount mounts a directory into one of Hugo's component folders, e.g.
+// content or static. Mounts are configured in module.mounts:
+//
+//	[[module.mounts]]
+//	source = "shared/content"
+//	target = "content"
+//
+// A project's own folders always win over its mounts, and if several mounts
+// provide the same file, the first one wins. Themes come last.
+type Mount struct {
+	// The directory to mount, relative to the working dir or absolute.
+	Source string
+
+	// The component folder to mount it into, e.g. "content" or "static".
+	Target string
+
+	// The language of mounted content. Default is the default content language.
+	Lang string
+}
+
+func decodeMounts(cfg config.Provider) ([]Mount, error) {
+	var mounts []Mount
+
+	if !cfg.IsSet(mountsConfigKey) {
+		return mounts, nil
+	}
+
+	if err := mapstructure.WeakDecode(cfg.Get(mountsConfigKey), &mounts); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %s", mountsConfigKey, err)
+	}
+
+	for i, m := range mounts {
+		m.Target = strings.Trim(strings.ToLower(m.Target), "/")
+		if m.Source == "" {
+			return nil, fmt.Errorf("mount %d: source not set", i)
+		}
+		if !mountTargets[m.Target] {
+			return nil, fmt.Errorf("mount %d: invalid target %q", i, m.Target)
+		}
+		mounts[i] = m
+	}
+
+	return mounts, nil
+}
+
+// mountsFor returns the mounts for the given target, in configuration order.
+func mountsFor(mounts []Mount, target string) []Mount {
+	var m []Mount
+	for _, mount := range mounts {
+		if mount.Target == target {
+			m = append(m, mount)
+		}
+	}
+	return m
+}
+
+// mountDirs returns the absolute, existing directories mounted into target.
+func (b *sourceFilesystemsBuilder) mountDirs(target string) []string {
+	return b.mountDirsForLang(target, "")
+}
+
+// mountDirsForLang is like mountDirs, but if lang is set, mounts for other
+// languages are skipped.
+func (b *sourceFilesystemsBuilder) mountDirsForLang(target, lang string) []string {
+	var dirs []string
+	for _, m := range mountsFor(b.mounts, target) {
+		if lang != "" && m.Lang != "" && m.Lang != lang {
+			continue
+		}
+		absDir := b.p.AbsPathify(m.Source)
+		if b.existsInSource(absDir) {
+			dirs = append(dirs, absDir)
+		}
+	}
+	return dirs
+}
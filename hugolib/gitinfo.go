@@ -14,26 +14,37 @@
 package hugolib
 
 import (
+	"bytes"
+	"errors"
+	"os/exec"
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/bep/gitmap"
 	"github.com/gohugoio/hugo/config"
 	"github.com/gohugoio/hugo/helpers"
 )
 
+// GitAuthor is the author of a Git commit, respecting .mailmap.
+type GitAuthor struct {
+	Name  string
+	Email string
+}
+
 type gitInfo struct {
-	contentDir string
-	repo       *gitmap.GitRepo
+	contentDir   string
+	repo         *gitmap.GitRepo
+	contributors map[string][]GitAuthor
 }
 
-func (g *gitInfo) forPage(p *Page) (*gitmap.GitInfo, bool) {
+func (g *gitInfo) forPage(p *Page) (*gitmap.GitInfo, []GitAuthor, bool) {
 	if g == nil {
-		return nil, false
+		return nil, nil, false
 	}
 	name := path.Join(g.contentDir, filepath.ToSlash(p.Path()))
-	return g.repo.Files[name], true
+	return g.repo.Files[name], g.contributors[name], true
 }
 
 func newGitInfo(cfg config.Provider) (*gitInfo, error) {
@@ -42,7 +53,7 @@ func newGitInfo(cfg config.Provider) (*gitInfo, error) {
 		contentDir = cfg.GetString("contentDir")
 	)
 
-	gitRepo, err := gitmap.Map(workingDir, "")
+	gitRepo, contributors, err := mapGitRepo(workingDir)
 	if err != nil {
 		return nil, err
 	}
@@ -55,5 +66,87 @@ func newGitInfo(cfg config.Provider) (*gitInfo, error) {
 	contentRoot = strings.TrimPrefix(contentRoot, helpers.FilePathSeparator)
 	contentDir = path.Join(filepath.ToSlash(contentRoot), contentDir)
 
-	return &gitInfo{contentDir: contentDir, repo: gitRepo}, nil
+	return &gitInfo{contentDir: contentDir, repo: gitRepo, contributors: contributors}, nil
+}
+
+// mapGitRepo works like gitmap.Map, but also collects the authors of the
+// commits touching each file, newest first, in the same pass over the log.
+func mapGitRepo(dir string) (*gitmap.GitRepo, map[string][]GitAuthor, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out, err := git("-C", dir, "rev-parse", "--show-cdup")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	topLevelPath := filepath.ToSlash(filepath.Join(absDir, strings.TrimSpace(string(out))))
+
+	// Without core.quotepath=false, non-ASCII filenames are quoted.
+	out, err = git("-c", "core.quotepath=false", "-C", dir, "log", "--name-only", "--no-merges",
+		"--format=format:%x1e%H%x1f%h%x1f%s%x1f%aN%x1f%aE%x1f%ai")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		files        = make(gitmap.GitMap)
+		contributors = make(map[string][]GitAuthor)
+		seen         = make(map[string]map[GitAuthor]bool)
+	)
+
+	for _, entry := range strings.Split(strings.Trim(string(out), "\n\x1e'"), "\x1e") {
+		lines := strings.Split(entry, "\n")
+		items := strings.Split(lines[0], "\x1f")
+		if len(items) != 6 {
+			continue
+		}
+
+		authorDate, err := time.Parse("2006-01-02 15:04:05 -0700", items[5])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		gi := &gitmap.GitInfo{
+			Hash:            items[0],
+			AbbreviatedHash: items[1],
+			Subject:         items[2],
+			AuthorName:      items[3],
+			AuthorEmail:     items[4],
+			AuthorDate:      authorDate,
+		}
+		author := GitAuthor{Name: gi.AuthorName, Email: gi.AuthorEmail}
+
+		for _, filename := range lines[1:] {
+			filename = strings.TrimSpace(filename)
+			if filename == "" {
+				continue
+			}
+			if _, found := files[filename]; !found {
+				files[filename] = gi
+			}
+			if seen[filename] == nil {
+				seen[filename] = make(map[GitAuthor]bool)
+			}
+			if !seen[filename][author] {
+				seen[filename][author] = true
+				contributors[filename] = append(contributors[filename], author)
+			}
+		}
+	}
+
+	return &gitmap.GitRepo{TopLevelAbsPath: topLevelPath, Files: files}, contributors, nil
+}
+
+func git(args ...string) ([]byte, error) {
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		if ee, ok := err.(*exec.Error); ok && ee.Err == exec.ErrNotFound {
+			return nil, gitmap.GitNotFound
+		}
+		return nil, errors.New(string(bytes.TrimSpace(out)))
+	}
+	return out, nil
 }
@@ -0,0 +1,138 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/hugofs"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitInfoDates(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	t.Parallel()
+
+	assert := require.New(t)
+
+	workDir, err := ioutil.TempDir("", "hugo-gitinfo")
+	assert.NoError(err)
+	defer os.RemoveAll(workDir)
+
+	contentDir := filepath.Join(workDir, "content")
+	assert.NoError(os.MkdirAll(contentDir, 0755))
+
+	assert.NoError(ioutil.WriteFile(filepath.Join(contentDir, "committed.md"), []byte("---\ntitle: Committed\n---\n"), 0644))
+
+	gitAs := func(author, date string, args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", workDir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME="+author, "GIT_AUTHOR_EMAIL="+strings.ToLower(author)+"@example.com",
+			"GIT_COMMITTER_NAME="+author, "GIT_COMMITTER_EMAIL="+strings.ToLower(author)+"@example.com",
+			"GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date,
+		)
+		out, err := cmd.CombinedOutput()
+		assert.NoError(err, string(out))
+	}
+	git := func(args ...string) {
+		gitAs("Hugo", "2017-08-14T10:00:00+00:00", args...)
+	}
+
+	git("init", "-q")
+	git("add", "content/committed.md")
+	git("commit", "-q", "-m", "Add committed page")
+
+	// A file with several contributors.
+	shared := filepath.Join(contentDir, "shared.md")
+	for i, author := range []string{"Alice", "Bob", "Alice", "Carol"} {
+		assert.NoError(ioutil.WriteFile(shared, []byte(fmt.Sprintf("---\ntitle: Shared\n---\nRevision %d\n", i)), 0644))
+		gitAs(author, fmt.Sprintf("2017-09-%02dT10:00:00+00:00", i+1), "add", "content/shared.md")
+		gitAs(author, fmt.Sprintf("2017-09-%02dT10:00:00+00:00", i+1), "commit", "-q", "-m", fmt.Sprintf("Edit %d", i))
+	}
+
+	// Git quotes non-ASCII filenames by default.
+	assert.NoError(ioutil.WriteFile(filepath.Join(contentDir, "café.md"), []byte("---\ntitle: Café\n---\n"), 0644))
+	gitAs("Dave", "2017-10-01T10:00:00+00:00", "add", "content/café.md")
+	gitAs("Dave", "2017-10-01T10:00:00+00:00", "commit", "-q", "-m", "Add café")
+
+	// This file is not in Git.
+	untracked := filepath.Join(contentDir, "untracked.md")
+	assert.NoError(ioutil.WriteFile(untracked, []byte("---\ntitle: Untracked\n---\n"), 0644))
+	modTime := time.Date(2016, 3, 4, 0, 0, 0, 0, time.UTC)
+	assert.NoError(os.Chtimes(untracked, modTime, modTime))
+
+	cfg := viper.New()
+	fs := hugofs.NewFrom(hugofs.Os, cfg)
+	fs.Destination = &afero.MemMapFs{}
+
+	cfg.Set("enableGitInfo", true)
+	cfg.Set("workingDir", workDir)
+	// Take the dates from Git, falling back to the modification time for
+	// files not in Git.
+	cfg.Set("frontmatter", map[string]interface{}{
+		"date":    []string{"date", ":git", ":fileModTime"},
+		"lastmod": []string{":git", "lastmod", ":fileModTime"},
+	})
+	assert.NoError(loadDefaultSettingsFor(cfg))
+
+	s := buildSingleSite(t, deps.DepsCfg{Fs: fs, Cfg: cfg}, BuildCfg{SkipRender: true})
+
+	assert.Len(s.RegularPages, 4)
+
+	committed := s.getPage(KindPage, "committed.md")
+	assert.NotNil(committed)
+	assert.NotNil(committed.GitInfo)
+	assert.Equal("Hugo", committed.GitInfo.AuthorName)
+	assert.Equal([]GitAuthor{{Name: "Hugo", Email: "hugo@example.com"}}, committed.GitContributors())
+	assert.Equal("Add committed page", committed.GitInfo.Subject)
+	assert.Len(committed.GitInfo.Hash, 40)
+	assert.Equal("2017-08-14", committed.Lastmod.UTC().Format("2006-01-02"))
+	// No date in front matter, so that is taken from Git, too.
+	assert.Equal("2017-08-14", committed.Date.UTC().Format("2006-01-02"))
+
+	sharedPage := s.getPage(KindPage, "shared.md")
+	assert.NotNil(sharedPage)
+	assert.NotNil(sharedPage.GitInfo)
+	assert.Equal("Carol", sharedPage.GitInfo.AuthorName)
+	assert.Equal([]GitAuthor{
+		{Name: "Carol", Email: "carol@example.com"},
+		{Name: "Alice", Email: "alice@example.com"},
+		{Name: "Bob", Email: "bob@example.com"},
+	}, sharedPage.GitContributors())
+
+	cafe := s.getPage(KindPage, "café.md")
+	assert.NotNil(cafe)
+	assert.NotNil(cafe.GitInfo)
+	assert.Equal("Add café", cafe.GitInfo.Subject)
+	assert.Equal([]GitAuthor{{Name: "Dave", Email: "dave@example.com"}}, cafe.GitContributors())
+
+	other := s.getPage(KindPage, "untracked.md")
+	assert.NotNil(other)
+	assert.Nil(other.GitInfo)
+	assert.Equal(modTime, other.Lastmod.UTC())
+	assert.Equal(modTime, other.Date.UTC())
+}
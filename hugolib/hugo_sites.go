@@ -15,6 +15,7 @@ package hugolib
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"path/filepath"
 	"sort"
@@ -28,6 +29,7 @@ import (
 	"github.com/gohugoio/hugo/i18n"
 	"github.com/gohugoio/hugo/tpl"
 	"github.com/gohugoio/hugo/tpl/tplimpl"
+	"github.com/spf13/afero"
 	jww "github.com/spf13/jwalterweatherman"
 )
 
@@ -43,6 +45,9 @@ type HugoSites struct {
 	// If this is running in the dev server.
 	running bool
 
+	// The build environment, e.g. "production" or "development".
+	environment string
+
 	*deps.Deps
 
 	// Keeps track of bundle directories and symlinks to enable partial rebuilding.
@@ -50,12 +55,72 @@ type HugoSites struct {
 
 	// If enabled, keeps a revision map for all content.
 	gitInfo *gitInfo
+
+	// Heading anchors referenced with ref and relref, validated when the
+	// build is done.
+	refAnchorsMu sync.Mutex
+	refAnchors   []refAnchor
+
+	// The Visitor of the current build, see BuildCfg.
+	visitorMu sync.Mutex
+	visitor   func(path string, content []byte) error
+
+	// Prints the memory usage during the build if printMemoryUsage is enabled.
+	memoryUsage *memoryUsageMonitor
+}
+
+// refAnchor is a fragment in a ref or relref to a page.
+type refAnchor struct {
+	ref    string
+	anchor string
+	target *Page
+}
+
+func (h *HugoSites) addRefAnchor(ref, anchor string, target *Page) {
+	h.refAnchorsMu.Lock()
+	h.refAnchors = append(h.refAnchors, refAnchor{ref: ref, anchor: anchor, target: target})
+	h.refAnchorsMu.Unlock()
+}
+
+// resetRefAnchors clears the ref anchors collected, e.g. in a build with
+// SkipRender where they are never validated.
+func (h *HugoSites) resetRefAnchors() {
+	h.refAnchorsMu.Lock()
+	h.refAnchors = nil
+	h.refAnchorsMu.Unlock()
+}
+
+// validateRefAnchors warns about ref anchors with no matching element ID
+// in the rendered content of the target page.
+func (h *HugoSites) validateRefAnchors() {
+	h.refAnchorsMu.Lock()
+	anchors := h.refAnchors
+	h.refAnchors = nil
+	h.refAnchorsMu.Unlock()
+
+	seen := make(map[refAnchor]bool)
+	for _, a := range anchors {
+		if seen[a] {
+			continue
+		}
+		seen[a] = true
+		if !strings.Contains(string(a.target.content()), `id="`+a.anchor+`"`) {
+			h.Warnings.Printf(h.Log.WARN, helpers.WarningUnknownAnchor, "Anchor %q in ref %q not found in page %q", a.anchor, a.ref, a.target.pathOrTitle())
+		}
+	}
 }
 
 func (h *HugoSites) IsMultihost() bool {
 	return h != nil && h.multihost
 }
 
+// ReadPublishedFile reads the given file, relative to the publish dir, e.g.
+// "sitemap.xml". This is useful when rendering to memory, see
+// deps.DepsCfg.RenderToMemory.
+func (h *HugoSites) ReadPublishedFile(filename string) ([]byte, error) {
+	return afero.ReadFile(h.PathSpec.BaseFs.PublishFs, filepath.Clean(filename))
+}
+
 func (h *HugoSites) NumLogErrors() int {
 	if h == nil {
 		return 0
@@ -69,6 +134,26 @@ func (h *HugoSites) PrintProcessingStats(w io.Writer) {
 		stats[i] = h.Sites[i].PathSpec.ProcessingStats
 	}
 	helpers.ProcessingStatsTable(w, stats...)
+
+	if summary := h.Warnings.Summary(); summary != "" {
+		fmt.Fprintf(w, "\nWarnings: %s\n", summary)
+	}
+}
+
+// visitRendered passes a rendered page to the Visitor of the current build,
+// if set.
+func (h *HugoSites) visitRendered(path string, content []byte) error {
+	if h == nil || h.visitor == nil {
+		return nil
+	}
+
+	h.visitorMu.Lock()
+	defer h.visitorMu.Unlock()
+
+	// The content is backed by a pooled buffer, so pass a copy.
+	path = strings.TrimPrefix(filepath.Clean(path), helpers.FilePathSeparator)
+
+	return h.visitor(path, append([]byte(nil), content...))
 }
 
 func (h *HugoSites) langSite() map[string]*Site {
@@ -121,6 +206,7 @@ func newHugoSites(cfg deps.DepsCfg, sites ...*Site) (*HugoSites, error) {
 
 	h := &HugoSites{
 		running:      cfg.Running,
+		environment:  helpers.BuildEnvironment(cfg.Cfg, cfg.Running),
 		multilingual: langConfig,
 		multihost:    cfg.Cfg.GetBool("multihost"),
 		Sites:        sites}
@@ -341,6 +427,11 @@ type BuildCfg struct {
 	whatChanged *whatChanged
 	// Recently visited URLs. This is used for partial re-rendering.
 	RecentlyVisited map[string]bool
+	// If set, Visitor is called with the target path, relative to the publish
+	// dir, and the content of every page, feed and sitemap rendered in this
+	// build, before it is written. The calls are serialized. Returning an
+	// error fails the build.
+	Visitor func(path string, content []byte) error
 }
 
 // shouldRender is used in the Fast Render Mode to determine if we need to re-render
@@ -529,7 +620,7 @@ func (h *HugoSites) setupTranslations() {
 				p.Kind = p.s.kindFromSections(p.sections)
 			}
 
-			if !p.s.isEnabled(p.Kind) {
+			if !p.s.isEnabledInSection(p.Kind, p.sections) {
 				continue
 			}
 
@@ -32,11 +32,24 @@ func (h *HugoSites) Build(config BuildCfg, events ...fsnotify.Event) error {
 		h.Metrics.Reset()
 	}
 
+	h.TemplateUsage.Reset()
+
+	if h.Cfg.GetBool("printMemoryUsage") {
+		h.memoryUsage = newMemoryUsageMonitor(h.Log.FEEDBACK, memoryUsageInterval)
+		h.memoryUsage.Start()
+		defer h.memoryUsage.Stop()
+	}
+
+	h.Warnings.Reset()
+	h.resetRefAnchors()
+
 	//t0 := time.Now()
 
 	// Need a pointer as this may be modified.
 	conf := &config
 
+	h.visitor = conf.Visitor
+
 	if conf.whatChanged == nil {
 		// Assume everything has changed
 		conf.whatChanged = &whatChanged{source: true, other: true}
@@ -48,6 +61,9 @@ func (h *HugoSites) Build(config BuildCfg, events ...fsnotify.Event) error {
 			return err
 		}
 	} else {
+		if tracker := h.PathSpec.BaseFs.PublishTracker; tracker != nil {
+			tracker.Reset()
+		}
 		if err := h.init(conf); err != nil {
 			return err
 		}
@@ -65,6 +81,10 @@ func (h *HugoSites) Build(config BuildCfg, events ...fsnotify.Event) error {
 		return err
 	}
 
+	if !conf.SkipRender {
+		h.validateRefAnchors()
+	}
+
 	if h.Metrics != nil {
 		var b bytes.Buffer
 		h.Metrics.WriteMetrics(&b)
@@ -74,6 +94,16 @@ func (h *HugoSites) Build(config BuildCfg, events ...fsnotify.Event) error {
 		h.Log.FEEDBACK.Println()
 	}
 
+	if h.TemplateUsage != nil && !conf.SkipRender {
+		if unused := h.TemplateUsage.Unused(); len(unused) > 0 {
+			h.Log.FEEDBACK.Printf("\nUnused Templates:\n\n")
+			for _, name := range unused {
+				h.Log.FEEDBACK.Println(name)
+			}
+			h.Log.FEEDBACK.Println()
+		}
+	}
+
 	errorCount := h.Log.LogCountForLevel(jww.LevelError)
 	if errorCount > 0 {
 		return fmt.Errorf("logged %d error(s)", errorCount)
@@ -188,6 +218,8 @@ func (h *HugoSites) assemble(config *BuildCfg) error {
 	}
 
 	for _, s := range h.Sites {
+		s.applyCascade()
+
 		for _, pages := range []Pages{s.Pages, s.headlessPages} {
 			for _, p := range pages {
 				// May have been set in front matter
@@ -199,6 +231,10 @@ func (h *HugoSites) assemble(config *BuildCfg) error {
 					// headless = 1 output format only
 					p.outputFormats = p.outputFormats[:1]
 				}
+
+				if p.Kind == KindSection && !s.isEnabledInSection(kindRSS, p.sections) {
+					p.outputFormats = withoutOutputFormat(p.outputFormats, kindRSS)
+				}
 				for _, r := range p.Resources.ByType(pageResourceType) {
 					r.(*Page).outputFormats = p.outputFormats
 				}
@@ -1405,3 +1405,33 @@ PNG Data
 
 	return &multiSiteTestBuilder{sitesBuilder: b, configFormat: configFormat, config: config, configData: configData}
 }
+
+func TestBuildWarningsSummary(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "http://example.com/"
+logI18nWarnings = true
+`)
+	b.WithContent("p1.md", "---\ntitle: P1\n---\n", "p2.md", "---\ntitle: P2\n---\n")
+	b.WithI18n("en.toml", `[hello]
+other = "Hello"
+`)
+	b.WithTemplatesAdded("_default/single.html", `{{ i18n "hello" }}|{{ i18n "missing" }}|{{ ref . "nope.md" }}`)
+
+	b.CreateSites().BuildFail(BuildCfg{})
+
+	assert.Equal(2, b.H.Warnings.Count(helpers.WarningBrokenRef))
+	assert.Equal(2, b.H.Warnings.Count(helpers.WarningMissingTranslation))
+
+	var buf bytes.Buffer
+	b.H.PrintProcessingStats(&buf)
+	assert.Contains(buf.String(), "Warnings: 2 broken refs, 2 missing translations")
+
+	b.H.Warnings.Reset()
+	buf.Reset()
+	b.H.PrintProcessingStats(&buf)
+	assert.NotContains(buf.String(), "Warnings")
+}
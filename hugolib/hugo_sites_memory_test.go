@@ -0,0 +1,66 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/hugofs"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderToMemory(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	workDir, err := ioutil.TempDir("", "hugo-memory")
+	assert.NoError(err)
+	defer os.RemoveAll(workDir)
+
+	for _, dir := range []string{"content", filepath.Join("layouts", "_default")} {
+		assert.NoError(os.MkdirAll(filepath.Join(workDir, dir), 0755))
+	}
+
+	cfg := viper.New()
+	cfg.Set("workingDir", workDir)
+	cfg.Set("baseURL", "http://example.com/")
+	loadDefaultSettingsFor(cfg)
+	assert.NoError(loadLanguageSettings(cfg, nil))
+
+	fs := hugofs.NewDefault(cfg)
+	writeSource(t, fs, filepath.Join(workDir, "content", "p1.md"), "---\ntitle: p1\n---\n")
+	writeSource(t, fs, filepath.Join(workDir, "layouts", "_default", "single.html"), "Single: {{ .Title }}")
+
+	h, err := NewHugoSites(deps.DepsCfg{Fs: fs, Cfg: cfg, RenderToMemory: true})
+	assert.NoError(err)
+	assert.NoError(h.Build(BuildCfg{}))
+
+	sitemap, err := h.ReadPublishedFile("sitemap.xml")
+	assert.NoError(err)
+	assert.Contains(string(sitemap), "<loc>http://example.com/p1/</loc>")
+
+	single, err := h.ReadPublishedFile("p1/index.html")
+	assert.NoError(err)
+	assert.Equal("Single: p1", string(single))
+
+	// Nothing is written to disk.
+	_, err = os.Stat(filepath.Join(workDir, "public"))
+	assert.True(os.IsNotExist(err))
+}
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/gohugoio/hugo/common/loggers"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
 )
 
 func TestThemesGraph(t *testing.T) {
@@ -266,3 +268,67 @@ params: {{ partial "m" .Site.Params }}
 	}
 
 }
+
+func TestThemesOverrideOrder(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org"
+theme = ["mytheme", "basetheme"]
+`)
+
+	for _, theme := range []string{"mytheme", "basetheme"} {
+		root := filepath.Join("themes", theme)
+		b.WithSourceFile(filepath.Join(root, "layouts", "partials", "both.html"), theme)
+		b.WithSourceFile(filepath.Join(root, "layouts", "partials", "project.html"), theme)
+		b.WithSourceFile(filepath.Join(root, "layouts", "partials", theme+".html"), theme)
+		b.WithSourceFile(filepath.Join(root, "i18n", "en.toml"), fmt.Sprintf(`
+[both]
+other = %q
+[project]
+other = %q
+[%s]
+other = %q
+`, theme, theme, theme, theme))
+		b.WithSourceFile(filepath.Join(root, "static", "both.txt"), theme)
+		b.WithSourceFile(filepath.Join(root, "static", "project.txt"), theme)
+		b.WithSourceFile(filepath.Join(root, "static", theme+".txt"), theme)
+	}
+
+	// A project file always wins.
+	b.WithSourceFile(filepath.Join("layouts", "partials", "project.html"), "project")
+	b.WithSourceFile(filepath.Join("i18n", "en.toml"), `
+[project]
+other = "project"
+`)
+	b.WithSourceFile(filepath.Join("static", "project.txt"), "project")
+
+	b.WithContent("page.md", "---\ntitle: Page\n---\n")
+	b.WithTemplates("index.html", `
+Layouts: {{ partial "project.html" . }}|{{ partial "both.html" . }}|{{ partial "mytheme.html" . }}|{{ partial "basetheme.html" . }}|
+I18n: {{ i18n "project" }}|{{ i18n "both" }}|{{ i18n "mytheme" }}|{{ i18n "basetheme" }}|
+`)
+
+	b.Build(BuildCfg{})
+
+	// The left-most theme wins.
+	b.AssertFileContent("public/index.html",
+		"Layouts: project|mytheme|mytheme|basetheme|",
+		"I18n: project|mytheme|mytheme|basetheme|",
+	)
+
+	staticFs := b.H.Sites[0].BaseFs.StaticFs("")
+	for filename, expected := range map[string]string{
+		"project.txt":   "project",
+		"both.txt":      "mytheme",
+		"mytheme.txt":   "mytheme",
+		"basetheme.txt": "basetheme",
+	} {
+		content, err := afero.ReadFile(staticFs, filename)
+		assert.NoError(err, filename)
+		assert.Equal(expected, string(content), filename)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gohugoio/hugo/helpers"
+)
+
+// How often the memory usage is printed during the build if
+// printMemoryUsage is enabled.
+const memoryUsageInterval = time.Second
+
+// memoryUsageMonitor prints the heap usage periodically while a build is
+// running, and once more when it's done.
+type memoryUsageMonitor struct {
+	logger   helpers.LogPrinter
+	interval time.Duration
+	start    time.Time
+
+	readings uint64
+
+	quit chan struct{}
+	done chan struct{}
+}
+
+func newMemoryUsageMonitor(logger helpers.LogPrinter, interval time.Duration) *memoryUsageMonitor {
+	return &memoryUsageMonitor{
+		logger:   logger,
+		interval: interval,
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (m *memoryUsageMonitor) Start() {
+	m.start = time.Now()
+
+	go func() {
+		defer close(m.done)
+
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.read()
+			case <-m.quit:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic readings and prints a final one.
+func (m *memoryUsageMonitor) Stop() {
+	close(m.quit)
+	<-m.done
+	m.read()
+}
+
+func (m *memoryUsageMonitor) read() {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	atomic.AddUint64(&m.readings, 1)
+
+	m.logger.Println(fmt.Sprintf("Memory usage after %s: HeapAlloc %s, HeapSys %s, TotalAlloc %s, NumGC %d",
+		time.Since(m.start).Round(time.Millisecond), formatMiB(stats.HeapAlloc), formatMiB(stats.HeapSys), formatMiB(stats.TotalAlloc), stats.NumGC))
+}
+
+// Readings returns the number of readings printed so far.
+func (m *memoryUsageMonitor) Readings() uint64 {
+	return atomic.LoadUint64(&m.readings)
+}
+
+func formatMiB(b uint64) string {
+	return strconv.FormatFloat(float64(b)/(1<<20), 'f', 1, 64) + " MiB"
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintMemoryUsage(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "http://example.com/"
+printMemoryUsage = true
+`)
+
+	for i := 1; i <= 10; i++ {
+		b.WithContent(fmt.Sprintf("p%d.md", i), fmt.Sprintf("---\ntitle: P%d\n---\nContent.", i))
+	}
+
+	b.Build(BuildCfg{})
+
+	assert.NotNil(b.H.memoryUsage)
+	assert.True(b.H.memoryUsage.Readings() >= 1)
+}
+
+func TestMemoryUsageMonitor(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var buf bytes.Buffer
+	m := newMemoryUsageMonitor(log.New(&buf, "", 0), 5*time.Millisecond)
+	m.Start()
+	time.Sleep(30 * time.Millisecond)
+	m.Stop()
+
+	// At least one periodic reading and the final one.
+	assert.True(m.Readings() >= 2)
+	assert.Contains(buf.String(), "Memory usage after")
+	assert.Contains(buf.String(), "HeapAlloc")
+}
@@ -35,6 +35,9 @@ type MenuEntry struct {
 	Weight     int
 	Parent     string
 	Children   Menu
+
+	// User defined params, e.g. an icon or a CSS class. The keys are lower case.
+	Params map[string]interface{}
 }
 
 // Menu is a collection of menu entries.
@@ -100,6 +103,15 @@ func (m *MenuEntry) marshallMap(ime map[string]interface{}) {
 			m.Identifier = cast.ToString(v)
 		case "parent":
 			m.Parent = cast.ToString(v)
+		case "params":
+			params, err := cast.ToStringMapE(v)
+			if err != nil {
+				continue
+			}
+			m.Params = make(map[string]interface{}, len(params))
+			for pk, pv := range params {
+				m.Params[strings.ToLower(pk)] = pv
+			}
 		}
 	}
 }
@@ -186,29 +198,40 @@ func (m Menu) Limit(n int) Menu {
 	return m
 }
 
-// ByWeight sorts the menu by the weight defined in the menu configuration.
+// ByWeight returns a copy of the menu sorted by the weight defined in the
+// menu configuration.
 func (m Menu) ByWeight() Menu {
-	menuEntryBy(defaultMenuEntrySort).Sort(m)
-	return m
+	menus := m.clone()
+	menuEntryBy(defaultMenuEntrySort).Sort(menus)
+	return menus
 }
 
-// ByName sorts the menu by the name defined in the menu configuration.
+// ByName returns a copy of the menu sorted by the name defined in the menu
+// configuration.
 func (m Menu) ByName() Menu {
 	title := func(m1, m2 *MenuEntry) bool {
 		return m1.Name < m2.Name
 	}
 
-	menuEntryBy(title).Sort(m)
-	return m
+	menus := m.clone()
+	menuEntryBy(title).Sort(menus)
+	return menus
 }
 
-// Reverse reverses the order of the menu entries.
+// Reverse returns a copy of the menu with the order of the entries reversed.
 func (m Menu) Reverse() Menu {
-	for i, j := 0, len(m)-1; i < j; i, j = i+1, j-1 {
-		m[i], m[j] = m[j], m[i]
+	menus := m.clone()
+	for i, j := 0, len(menus)-1; i < j; i, j = i+1, j-1 {
+		menus[i], menus[j] = menus[j], menus[i]
 	}
 
-	return m
+	return menus
+}
+
+// clone makes a shallow copy of the menu so it can be sorted without
+// changing the order of the menu shared by all pages.
+func (m Menu) clone() Menu {
+	return append(Menu(nil), m...)
 }
 
 func (m *MenuEntry) Title() string {
@@ -112,3 +112,148 @@ Menu Main:  {{ partial "menu.html" (dict "page" . "menu" "main") }}`,
 			"/sect3/|Sect3s||0|-|-|")
 
 }
+
+func TestSectionPagesMenuTopLevelOnly(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "http://example.com/"
+sectionPagesMenu = "main"
+`)
+
+	b.WithContent(
+		"blog/_index.md", "---\ntitle: Blog\nweight: 2\n---",
+		"blog/sub/_index.md", "---\ntitle: Blog Sub\nweight: 1\n---",
+		"blog/sub/p1.md", "---\ntitle: P1\n---",
+		"docs/_index.md", "---\ntitle: Docs\nweight: 1\n---",
+		"docs/p2.md", "---\ntitle: P2\n---",
+	)
+
+	b.WithTemplatesAdded("index.html", `Main: {{ range .Site.Menus.main }}{{ .Identifier }}:{{ .Name }}:{{ .URL }}|{{ end }}`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "Main: docs:Docs:/docs/|blog:Blog:/blog/|")
+}
+
+func TestMenuSortMethods(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "http://example.com/"
+
+[[menu.main]]
+name = "Charlie"
+url = "/c/"
+weight = 1
+[[menu.main]]
+name = "Alpha"
+url = "/a/"
+weight = 3
+[[menu.main]]
+name = "Bravo"
+url = "/b/"
+weight = 2
+`)
+
+	b.WithTemplatesAdded("index.html", `
+ByName: {{ range .Site.Menus.main.ByName }}{{ .Name }}|{{ end }}
+ByWeight: {{ range .Site.Menus.main.ByWeight }}{{ .Name }}|{{ end }}
+Reverse: {{ range .Site.Menus.main.Reverse }}{{ .Name }}|{{ end }}
+Default: {{ range .Site.Menus.main }}{{ .Name }}|{{ end }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html",
+		"ByName: Alpha|Bravo|Charlie|",
+		"ByWeight: Charlie|Bravo|Alpha|",
+		"Reverse: Alpha|Bravo|Charlie|",
+		// The sort methods must not change the order of the site menu.
+		"Default: Charlie|Bravo|Alpha|",
+	)
+}
+
+func TestMenuEntryParams(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "http://example.com/"
+
+[[menu.main]]
+name = "Config"
+url = "/config/"
+weight = 2
+[menu.main.params]
+icon = "gear"
+`)
+
+	b.WithContent("p1.md", `---
+title: P1
+menu:
+  main:
+    weight: 1
+    params:
+      Icon: home
+      class: active
+---
+`)
+
+	b.WithTemplatesAdded("index.html", `Main: {{ range .Site.Menus.main }}{{ .Name }}:{{ .Params.icon }}:{{ with .Params.class }}{{ . }}{{ else }}-{{ end }}|{{ end }}`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "Main: P1:home:active|Config:gear:-|")
+}
+
+func TestMenuCurrentNested(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "http://example.com/"
+
+[[menu.main]]
+name = "Docs"
+identifier = "docs"
+url = "/docs/"
+weight = 1
+[[menu.main]]
+name = "Guide"
+identifier = "guide"
+parent = "docs"
+url = "/docs/guide/"
+[[menu.main]]
+name = "Install"
+parent = "guide"
+url = "/docs/guide/install/"
+[[menu.main]]
+name = "Blog"
+url = "/blog/"
+weight = 2
+`)
+
+	b.WithContent(
+		"docs/guide/install.md", "---\ntitle: Install\n---",
+		"docs/guide/configure.md", `---
+title: Configure
+menu:
+  main:
+    parent: guide
+---`,
+	)
+
+	b.WithTemplatesAdded(
+		"_default/single.html", `{{ template "menu" (dict "page" . "entries" .Site.Menus.main) }}
+{{ define "menu" }}{{ $p := .page }}{{ range .entries }}{{ .Name }}:{{ if $p.IsMenuCurrent "main" . }}IsMenuCurrent{{ else if $p.HasMenuCurrent "main" . }}HasMenuCurrent{{ else }}-{{ end }}|{{ template "menu" (dict "page" $p "entries" .Children) }}{{ end }}{{ end }}`,
+	)
+
+	b.Build(BuildCfg{})
+
+	// A page only matched by its URL.
+	b.AssertFileContent("public/docs/guide/install/index.html",
+		"Docs:HasMenuCurrent|Guide:HasMenuCurrent|Configure:-|Install:IsMenuCurrent|Blog:-|")
+
+	// A page that adds itself to the menu in front matter.
+	b.AssertFileContent("public/docs/guide/configure/index.html",
+		"Docs:HasMenuCurrent|Guide:HasMenuCurrent|Configure:IsMenuCurrent|Install:-|Blog:-|")
+}
@@ -0,0 +1,77 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMounts(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org"
+
+[[module.mounts]]
+source = "shared/content"
+target = "content"
+
+[[module.mounts]]
+source = "blog/posts"
+target = "content"
+
+[[module.mounts]]
+source = "shared/static"
+target = "static"
+
+[[module.mounts]]
+source = "shared/layouts"
+target = "layouts"
+`)
+
+	b.WithContent("about.md", "---\ntitle: About\n---\n")
+	b.WithSourceFile(filepath.Join("shared", "content", "docs", "intro.md"), "---\ntitle: Shared Intro\n---\n")
+	b.WithSourceFile(filepath.Join("shared", "content", "docs", "setup.md"), "---\ntitle: Shared Setup\n---\n")
+	b.WithSourceFile(filepath.Join("blog", "posts", "docs", "setup.md"), "---\ntitle: Blog Setup\n---\n")
+	b.WithSourceFile(filepath.Join("blog", "posts", "post1.md"), "---\ntitle: Post 1\n---\n")
+	b.WithSourceFile(filepath.Join("shared", "static", "css", "main.css"), "body {}")
+	b.WithSourceFile(filepath.Join("shared", "layouts", "_default", "single.html"), "Single: {{ .Title }}")
+
+	b.WithTemplates("index.html", `{{ range .Site.RegularPages }}{{ .Title }}|{{ .RelPermalink }};{{ end }}`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html",
+		"About|/about/;",
+		"Post 1|/post1/;",
+		"Shared Intro|/docs/intro/;",
+		// The first mount wins.
+		"Shared Setup|/docs/setup/;",
+	)
+	b.AssertFileContent("public/docs/intro/index.html", "Single: Shared Intro")
+
+	s := b.H.Sites[0]
+	assert := require.New(t)
+	assert.Len(s.RegularPages, 4)
+
+	// Static files are copied outside of the site build.
+	css, err := afero.ReadFile(s.BaseFs.StaticFs(""), filepath.FromSlash("css/main.css"))
+	assert.NoError(err)
+	assert.Equal("body {}", string(css))
+}
@@ -34,11 +34,13 @@ import (
 	"github.com/gohugoio/hugo/helpers"
 	"github.com/gohugoio/hugo/hugolib/pagemeta"
 	"github.com/gohugoio/hugo/resource"
+	"github.com/gohugoio/hugo/tpl"
 
 	"github.com/gohugoio/hugo/output"
 	"github.com/gohugoio/hugo/parser"
 	"github.com/mitchellh/mapstructure"
 
+	"html"
 	"html/template"
 	"io"
 	"path"
@@ -165,6 +167,12 @@ type Page struct {
 
 	Layout string
 
+	// The front matter values this page passes on to its descendants, and the
+	// values this page got from its closest ancestor with a cascade.
+	// See applyCascade.
+	cascade  map[string]interface{}
+	cascaded map[string]interface{}
+
 	// For npn-renderable pages (see IsRenderable), the content itself
 	// is used as template and the template name is stored here.
 	selfLayout string
@@ -196,10 +204,13 @@ type Page struct {
 
 	Source
 
-	Position `json:"-"`
+	pagePosition `json:"-"`
 
 	GitInfo *gitmap.GitInfo
 
+	// The authors of the commits touching the content file. See GitContributors.
+	gitContributors []GitAuthor
+
 	// This was added as part of getting the Nodes (taxonomies etc.) to work as
 	// Pages in Hugo 0.18.
 	// It is deliberately named similar to Section, but not exported (for now).
@@ -230,7 +241,7 @@ type Page struct {
 
 	title       string
 	Description string
-	Keywords    []string
+	keywords    []string
 	data        map[string]interface{}
 
 	pagemeta.PageDates
@@ -239,6 +250,13 @@ type Page struct {
 	pagemeta.URLPath
 	frontMatterURL string
 
+	// Set from canonicalURL in front matter.
+	canonicalURL string
+
+	// Weights set on individual taxonomy terms in front matter, keyed by
+	// taxonomy (plural) and lower case term.
+	termWeights map[string]map[string]int
+
 	permalink    string
 	relPermalink string
 
@@ -273,6 +291,10 @@ type Page struct {
 	// Use with care, as there are potential for inifinite loops.
 	mainPageOutput *PageOutput
 
+	// Used when this page is paginated from another page's template, e.g.
+	// when ranging over .Sections. See Paginator.
+	paginatorPageOutput *PageOutput
+
 	targetPathDescriptorPrototype *targetPathDescriptor
 }
 
@@ -350,6 +372,11 @@ func (p *Page) content() template.HTML {
 	return p.contentv
 }
 
+// Len returns the length, in bytes, of the rendered content.
+func (p *Page) Len() int {
+	return len(p.content())
+}
+
 func (p *Page) Summary() template.HTML {
 	p.initContent()
 	return p.summary
@@ -417,7 +444,7 @@ func (p *Page) createLayoutDescriptor() output.LayoutDescriptor {
 		Kind:    p.Kind,
 		Type:    p.Type(),
 		Lang:    p.Lang(),
-		Layout:  p.Layout,
+		Layout:  p.layout(),
 		Section: section,
 	}
 }
@@ -430,6 +457,7 @@ type pageInit struct {
 	pageMetaInit        sync.Once
 	renderingConfigInit sync.Once
 	withoutContentInit  sync.Once
+	paginatorOutputInit sync.Once
 }
 
 type pageContentInit struct {
@@ -507,6 +535,10 @@ type Position struct {
 	NextInSection *Page
 }
 
+// pagePosition lets Page embed Position without the field name colliding
+// with the Position method.
+type pagePosition = Position
+
 type Pages []*Page
 
 func (ps Pages) String() string {
@@ -575,6 +607,8 @@ func (p *Page) createWorkContentCopy() {
 	copy(p.workContent, p.rawContent)
 }
 
+// Plain returns the rendered content as plain text, with the HTML tags
+// removed and the HTML entities decoded, for all content formats.
 func (p *Page) Plain() string {
 	p.initContent()
 	p.initPlain(true)
@@ -587,7 +621,9 @@ func (p *Page) initPlain(lock bool) {
 			p.contentInitMu.Lock()
 			defer p.contentInitMu.Unlock()
 		}
-		p.plain = helpers.StripHTML(string(p.contentv))
+		// Decode the entities after stripping the tags, so an escaped "<"
+		// in the text is not taken for the start of a tag.
+		p.plain = html.UnescapeString(helpers.StripHTML(string(p.contentv)))
 	})
 }
 
@@ -634,7 +670,7 @@ func (p *Page) Param(key interface{}) (interface{}, error) {
 func (p *Page) traverseDirect(key string) (interface{}, error) {
 	keyStr := strings.ToLower(key)
 	if val, ok := p.params[keyStr]; ok {
-		return val, nil
+		return mergeParams(val, p.Site.Params[keyStr]), nil
 	}
 
 	return p.Site.Params[keyStr], nil
@@ -643,7 +679,7 @@ func (p *Page) traverseDirect(key string) (interface{}, error) {
 func (p *Page) traverseNested(keySegments []string) (interface{}, error) {
 	result := traverse(keySegments, p.params)
 	if result != nil {
-		return result, nil
+		return mergeParams(result, traverse(keySegments, p.Site.Params)), nil
 	}
 
 	result = traverse(keySegments, p.Site.Params)
@@ -655,6 +691,26 @@ func (p *Page) traverseNested(keySegments []string) (interface{}, error) {
 	return nil, nil
 }
 
+// mergeParams deep merges the page param into the site param if both are maps,
+// so nested keys only set at the site level are preserved.
+// On any other conflict the page param wins, as the more specific source.
+func mergeParams(pageParam, siteParam interface{}) interface{} {
+	pm, ok := maps.AsStringMap(pageParam)
+	if !ok {
+		return pageParam
+	}
+	sm, ok := maps.AsStringMap(siteParam)
+	if !ok {
+		return pageParam
+	}
+
+	merged := make(map[string]interface{})
+	maps.MergeDeep(merged, sm)
+	maps.MergeDeep(merged, pm)
+
+	return merged
+}
+
 func traverse(keys []string, m map[string]interface{}) interface{} {
 	// Shift first element off.
 	firstKey, rest := keys[0], keys[1:]
@@ -674,6 +730,35 @@ func traverse(keys []string, m map[string]interface{}) interface{} {
 	return traverse(rest, cast.ToStringMap(result))
 }
 
+// Keywords returns the keywords for this page, taken from the front matter
+// fields set in keywordSources, by default the keywords followed by the tags.
+// Duplicates are removed, ignoring case, keeping the first occurrence.
+func (p *Page) Keywords() []string {
+	keywords := make([]string, 0)
+	seen := make(map[string]bool)
+
+	add := func(kw string) {
+		kw = strings.TrimSpace(kw)
+		if kw == "" {
+			return
+		}
+		lkw := strings.ToLower(kw)
+		if seen[lkw] {
+			return
+		}
+		seen[lkw] = true
+		keywords = append(keywords, kw)
+	}
+
+	for _, source := range p.s.Cfg.GetStringSlice("keywordSources") {
+		for _, kw := range cast.ToStringSlice(p.params[strings.ToLower(source)]) {
+			add(kw)
+		}
+	}
+
+	return keywords
+}
+
 func (p *Page) Author() Author {
 	authors := p.Authors()
 
@@ -856,6 +941,8 @@ func splitUserDefinedSummaryAndContent(markup string, c []byte) (sc *summaryCont
 	return
 }
 
+var plainToHTMLReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
 func (p *Page) setAutoSummary() error {
 	var summary string
 	var truncated bool
@@ -869,7 +956,8 @@ func (p *Page) setAutoSummary() error {
 	} else {
 		summary, truncated = p.s.ContentSpec.TruncateWordsToWholeSentence(p.plain)
 	}
-	p.summary = template.HTML(summary)
+	// The plain content has its entities decoded, so escape it again.
+	p.summary = template.HTML(plainToHTMLReplacer.Replace(summary))
 	p.truncated = truncated
 
 	return nil
@@ -881,7 +969,13 @@ func (p *Page) renderContent(content []byte) []byte {
 		Content: content, RenderTOC: true, PageFmt: p.Markup,
 		Cfg:        p.Language(),
 		DocumentID: p.UniqueID(), DocumentName: p.Path(),
-		Config: p.getRenderingConfig()})
+		Config: p.getRenderingConfig(), RenderHooks: p.renderHooks()})
+}
+
+// renderHooks returns the link and image render hooks for this page, or nil
+// if there are none.
+func (p *Page) renderHooks() *helpers.RenderHooks {
+	return tpl.NewRenderHooks(p.s.Tmpl, p)
 }
 
 func (p *Page) getRenderingConfig() *helpers.BlackFriday {
@@ -927,7 +1021,7 @@ func (s *Site) newPageFromFile(fi *fileInfo) *Page {
 		Kind:            kindFromFileInfo(fi),
 		contentType:     "",
 		Source:          Source{File: fi},
-		Keywords:        []string{}, Sitemap: Sitemap{Priority: -1},
+		keywords:        []string{}, Sitemap: Sitemap{Priority: -1},
 		params:       make(map[string]interface{}),
 		translations: make(Pages, 0),
 		sections:     sectionsFromFile(fi),
@@ -945,6 +1039,10 @@ func (p *Page) Type() string {
 		return p.contentType
 	}
 
+	if x := cast.ToString(p.cascaded["type"]); x != "" {
+		return x
+	}
+
 	if x := p.Section(); x != "" {
 		return x
 	}
@@ -1163,6 +1261,16 @@ func (p *Page) Permalink() string {
 	return p.permalink
 }
 
+// CanonicalURL returns the preferred URL for this page, used in e.g. the
+// internal canonical and Open Graph templates. It is the canonicalURL set in
+// front matter, made absolute using the baseURL, or the Permalink.
+func (p *Page) CanonicalURL() string {
+	if p.canonicalURL != "" {
+		return p.s.PathSpec.AbsURL(p.canonicalURL, false)
+	}
+	return p.Permalink()
+}
+
 // RelPermalink gets a URL to the resource relative to the host.
 func (p *Page) RelPermalink() string {
 	if p.headless {
@@ -1364,6 +1472,9 @@ func (p *Page) update(frontmatter map[string]interface{}) error {
 			p.URLPath.URL = cast.ToString(v)
 			p.frontMatterURL = p.URLPath.URL
 			p.params[loki] = p.URLPath.URL
+		case "canonicalurl":
+			p.canonicalURL = cast.ToString(v)
+			p.params[loki] = p.canonicalURL
 		case "type":
 			p.contentType = cast.ToString(v)
 			p.params[loki] = p.contentType
@@ -1371,8 +1482,8 @@ func (p *Page) update(frontmatter map[string]interface{}) error {
 			p.extension = cast.ToString(v)
 			p.params[loki] = p.extension
 		case "keywords":
-			p.Keywords = cast.ToStringSlice(v)
-			p.params[loki] = p.Keywords
+			p.keywords = cast.ToStringSlice(v)
+			p.params[loki] = p.keywords
 		case "headless":
 			// For now, only the leaf bundles ("index.md") can be headless (i.e. produce no output).
 			// We may expand on this in the future, but that gets more complex pretty fast.
@@ -1400,6 +1511,12 @@ func (p *Page) update(frontmatter map[string]interface{}) error {
 		case "layout":
 			p.Layout = cast.ToString(v)
 			p.params[loki] = p.Layout
+		case "cascade":
+			p.cascade = make(map[string]interface{})
+			for ck, cv := range cast.ToStringMap(v) {
+				p.cascade[strings.ToLower(ck)] = cv
+			}
+			p.params[loki] = p.cascade
 		case "markup":
 			p.Markup = cast.ToString(v)
 			p.params[loki] = p.Markup
@@ -1508,6 +1625,8 @@ func (p *Page) update(frontmatter map[string]interface{}) error {
 		}
 	}
 
+	p.prepareTaxonomyParams()
+
 	// Try markup explicitly set in the frontmatter
 	p.Markup = helpers.GuessType(p.Markup)
 	if p.Markup == "unknown" {
@@ -1584,6 +1703,76 @@ func (p *Page) getParam(key string, stringToLower bool) interface{} {
 	return nil
 }
 
+// prepareTaxonomyParams reads the taxonomy terms configured in taxonomyParams
+// from their nested front matter param, e.g. meta.categories, and handles
+// terms given with a weight, e.g. tags: [{name: go, weight: 1}]. The term
+// names are stored in the params as if given as a plain list, and the
+// weights in termWeights.
+func (p *Page) prepareTaxonomyParams() {
+	paths := p.s.Language.GetStringMapString("taxonomyParams")
+
+	for _, plural := range p.s.Language.GetStringMapString("taxonomies") {
+		v, found := p.params[plural]
+		if !found {
+			path, ok := paths[plural]
+			if !ok {
+				continue
+			}
+			v = traverse(strings.Split(strings.ToLower(path), "."), p.params)
+			if v == nil {
+				continue
+			}
+			p.params[plural] = v
+		}
+
+		var terms []interface{}
+		switch vv := v.(type) {
+		case []interface{}, []map[string]interface{}:
+			terms = cast.ToSlice(vv)
+		default:
+			continue
+		}
+
+		names := make([]string, 0, len(terms))
+		for _, term := range terms {
+			m, err := cast.ToStringMapE(term)
+			if err != nil {
+				names = append(names, cast.ToString(term))
+				continue
+			}
+
+			name := cast.ToString(m["name"])
+			if name == "" {
+				p.s.Log.ERROR.Printf("Missing name in %s term in %s", plural, p.File.Path())
+				continue
+			}
+			names = append(names, name)
+
+			if w, found := m["weight"]; found {
+				if p.termWeights == nil {
+					p.termWeights = make(map[string]map[string]int)
+				}
+				if p.termWeights[plural] == nil {
+					p.termWeights[plural] = make(map[string]int)
+				}
+				p.termWeights[plural][strings.ToLower(name)] = cast.ToInt(w)
+			}
+		}
+
+		p.params[plural] = names
+	}
+}
+
+// termWeight returns the weight of this page in the given taxonomy term.
+// A weight set on the term itself wins over the weight set for the
+// taxonomy, e.g. tags_weight.
+func (p *Page) termWeight(plural, term string, taxonomyWeight int) int {
+	if w, found := p.termWeights[plural][strings.ToLower(term)]; found {
+		return w
+	}
+	return taxonomyWeight
+}
+
 func (p *Page) HasMenuCurrent(menuID string, me *MenuEntry) bool {
 
 	sectionPagesMenu := p.Site.sectionPagesMenu
@@ -1616,11 +1805,8 @@ func (p *Page) HasMenuCurrent(menuID string, me *MenuEntry) bool {
 
 	}
 
-	if p.IsPage() {
-		return false
-	}
-
-	// The following logic is kept from back when Hugo had both Page and Node types.
+	// The page may also be in the menu by URL only, e.g. an entry in the
+	// site config pointing to it.
 	// TODO(bep) consolidate / clean
 	nme := MenuEntry{Page: p, Name: p.title, URL: p.URL()}
 
@@ -1647,11 +1833,8 @@ func (p *Page) IsMenuCurrent(menuID string, inme *MenuEntry) bool {
 		}
 	}
 
-	if p.IsPage() {
-		return false
-	}
-
-	// The following logic is kept from back when Hugo had both Page and Node types.
+	// The page may also be in the menu by URL only, e.g. an entry in the
+	// site config pointing to it.
 	// TODO(bep) consolidate / clean
 	me := MenuEntry{Page: p, Name: p.title, URL: p.URL()}
 
@@ -1775,9 +1958,10 @@ func (p *Page) parse(reader io.Reader) error {
 	}
 
 	if p.s != nil && p.s.owner != nil {
-		gi, enabled := p.s.owner.gitInfo.forPage(p)
+		gi, contributors, enabled := p.s.owner.gitInfo.forPage(p)
 		if gi != nil {
 			p.GitInfo = gi
+			p.gitContributors = contributors
 		} else if enabled {
 			p.s.Log.WARN.Printf("Failed to find GitInfo for page %q", p.Path())
 		}
@@ -1786,8 +1970,11 @@ func (p *Page) parse(reader io.Reader) error {
 	return p.update(meta)
 }
 
+// RawContent returns the source content without the front matter. Any blank
+// lines between the front matter and the content are removed, so this is the
+// same for all content formats.
 func (p *Page) RawContent() string {
-	return string(p.rawContent)
+	return strings.TrimLeft(string(p.rawContent), "\r\n")
 }
 
 func (p *Page) SetSourceContent(content []byte) {
@@ -2011,6 +2198,12 @@ func (p *Page) copy(initContent bool) *Page {
 	return &c
 }
 
+// GitContributors returns the authors of all the commits touching the content
+// file, newest first, each listed once. It is only set with enableGitInfo.
+func (p *Page) GitContributors() []GitAuthor {
+	return p.gitContributors
+}
+
 func (p *Page) Hugo() *HugoInfo {
 	return hugoInfo
 }
@@ -14,8 +14,10 @@
 package hugolib
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 	"sort"
 
@@ -195,15 +197,18 @@ func (c *contentHandlers) parsePage(h contentHandler) contentHandler {
 		result := handlerResult{handled: true}
 		fi := ctx.file()
 
-		f, err := fi.Open()
+		content, err := c.readContentFile(fi)
 		if err != nil {
-			return handlerResult{err: fmt.Errorf("(%s) failed to open content file: %s", fi.Filename(), err)}
+			return handlerResult{err: err}
+		}
+		if content == nil {
+			// Encrypted and no key set.
+			return result
 		}
-		defer f.Close()
 
 		p := c.s.newPageFromFile(fi)
 
-		_, err = p.ReadFrom(f)
+		_, err = p.ReadFrom(bytes.NewReader(content))
 		if err != nil {
 			return handlerResult{err: err}
 		}
@@ -264,6 +269,39 @@ func (c *contentHandlers) parsePage(h contentHandler) contentHandler {
 	}
 }
 
+// readContentFile reads the given content file, decrypting it with the
+// contentEncryptionKey if it is encrypted. If the file is encrypted and no
+// key is set, nil is returned and the file should be skipped.
+func (c *contentHandlers) readContentFile(fi *fileInfo) ([]byte, error) {
+	f, err := fi.Open()
+	if err != nil {
+		return nil, fmt.Errorf("(%s) failed to open content file: %s", fi.Filename(), err)
+	}
+	defer f.Close()
+
+	b, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("(%s) failed to read content file: %s", fi.Filename(), err)
+	}
+
+	if !helpers.IsEncryptedContent(b) {
+		return b, nil
+	}
+
+	key := c.s.Cfg.GetString("contentEncryptionKey")
+	if key == "" {
+		c.s.Log.INFO.Printf("Skipping encrypted content file %q: contentEncryptionKey not set", fi.Filename())
+		return nil, nil
+	}
+
+	b, err = helpers.DecryptContent(key, b)
+	if err != nil {
+		return nil, fmt.Errorf("(%s) failed to decrypt content file: %s", fi.Filename(), err)
+	}
+
+	return b, nil
+}
+
 func (c *contentHandlers) handlePageContent() contentHandler {
 	return func(ctx *handlerContext) handlerResult {
 		if ctx.supports("html", "htm") {
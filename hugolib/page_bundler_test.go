@@ -755,3 +755,43 @@ TheContent.
 
 	return ps, workDir
 }
+
+func TestEncryptedContent(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	encrypted, err := helpers.EncryptContent("secret", []byte("---\ntitle: Preview\n---\nThe preview content."))
+	assert.NoError(err)
+
+	newBuilder := func(key string) *sitesBuilder {
+		b := newTestSitesBuilder(t).WithConfigFile("toml", fmt.Sprintf(`
+baseURL = "http://example.com/"
+contentEncryptionKey = %q
+`, key))
+		b.WithContent(
+			"preview/p1.md", string(encrypted),
+			"p2.md", "---\ntitle: Public\n---\nThe public content.",
+		)
+		b.WithTemplatesAdded("_default/single.html", "Single: {{ .Title }}|{{ .Content }}")
+		return b
+	}
+
+	b := newBuilder("secret")
+	b.Build(BuildCfg{})
+	b.AssertFileContent("public/preview/p1/index.html", "Single: Preview|<p>The preview content.</p>")
+	b.AssertFileContent("public/p2/index.html", "Single: Public")
+
+	// Without a key the encrypted files are skipped.
+	b = newBuilder("")
+	b.Build(BuildCfg{})
+	assert.False(b.CheckExists("public/preview/p1/index.html"))
+	b.AssertFileContent("public/p2/index.html", "Single: Public")
+
+	b = newBuilder("wrong")
+	b.CreateSites()
+	err = b.H.Build(BuildCfg{})
+	assert.Error(err)
+	assert.Contains(err.Error(), "failed to decrypt content file")
+	assert.Contains(err.Error(), "wrong key")
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"github.com/spf13/cast"
+)
+
+// The front matter keys that can be set for all descendants of a section
+// or the home page in a cascade block, e.g.:
+//
+//	cascade:
+//	  type: docs
+//	  layout: reference
+var cascadeKeys = []string{"type", "layout"}
+
+// applyCascade resolves the cascaded front matter values for the pages in this
+// site. A value set in a page's own front matter always wins; otherwise the
+// closest ancestor with the key set in its cascade wins.
+// This must be run after the section tree is assembled.
+func (s *Site) applyCascade() {
+	for _, p := range s.Pages {
+		p.cascaded = nil
+
+		for _, key := range cascadeKeys {
+			for ancestor := p.parent; ancestor != nil; ancestor = ancestor.parent {
+				if v, found := ancestor.cascade[key]; found {
+					if p.cascaded == nil {
+						p.cascaded = make(map[string]interface{})
+					}
+					p.cascaded[key] = v
+					break
+				}
+			}
+		}
+	}
+}
+
+// layout returns the layout set in front matter or cascaded from an ancestor.
+func (p *Page) layout() string {
+	if p.Layout != "" {
+		return p.Layout
+	}
+	return cast.ToString(p.cascaded["layout"])
+}
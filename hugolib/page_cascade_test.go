@@ -0,0 +1,85 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCascade(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent(
+		"_index.md", `---
+title: Home
+cascade:
+  layout: fromhome
+---
+`,
+		"about.md", `---
+title: About
+---
+`,
+		filepath.Join("docs", "_index.md"), `---
+title: Docs
+cascade:
+  type: manual
+---
+`,
+		filepath.Join("docs", "intro.md"), `---
+title: Intro
+---
+`,
+		filepath.Join("docs", "explicit.md"), `---
+title: Explicit
+type: blog
+layout: mine
+---
+`,
+		filepath.Join("docs", "api", "_index.md"), `---
+title: API
+cascade:
+  layout: reference
+---
+`,
+		filepath.Join("docs", "api", "func.md"), `---
+title: Func
+---
+`,
+		filepath.Join("other", "page.md"), `---
+title: Other
+---
+`,
+	)
+
+	b.WithTemplates(
+		"_default/single.html", `Default: {{ .Title }}|{{ .Type }}`,
+		"_default/fromhome.html", `From home: {{ .Title }}|{{ .Type }}`,
+		"manual/fromhome.html", `Manual from home: {{ .Title }}|{{ .Type }}`,
+		"manual/reference.html", `Manual reference: {{ .Title }}|{{ .Type }}`,
+		"blog/mine.html", `Blog mine: {{ .Title }}|{{ .Type }}`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/about/index.html", "From home: About|page")
+	b.AssertFileContent("public/other/page/index.html", "From home: Other|other")
+	b.AssertFileContent("public/docs/intro/index.html", "Manual from home: Intro|manual")
+	b.AssertFileContent("public/docs/explicit/index.html", "Blog mine: Explicit|blog")
+	// The closest cascade wins.
+	b.AssertFileContent("public/docs/api/func/index.html", "Manual reference: Func|manual")
+}
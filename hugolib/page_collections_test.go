@@ -138,3 +138,25 @@ func TestGetPage(t *testing.T) {
 	}
 
 }
+
+func TestGetPageStrict(t *testing.T) {
+	t.Parallel()
+
+	for _, strict := range []bool{false, true} {
+		b := newTestSitesBuilder(t)
+		b.WithConfigFile("toml", fmt.Sprintf(`
+baseURL = "http://example.com/"
+strictGetPage = %t
+`, strict))
+		b.WithContent("sect/p1.md", "---\ntitle: P1\n---\n")
+		b.WithTemplatesAdded("index.html", `Found: {{ with .Site.GetPage "page" "sect/p1.md" }}{{ .Title }}{{ end }}|Missing: {{ with .Site.GetPage "page" "sect/nope.md" }}{{ .Title }}{{ end }}`)
+
+		if strict {
+			b.BuildFail(BuildCfg{})
+			continue
+		}
+
+		b.Build(BuildCfg{})
+		b.AssertFileContent("public/index.html", "Found: P1|Missing: ")
+	}
+}
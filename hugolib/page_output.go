@@ -85,8 +85,13 @@ func newPageOutput(p *Page, createCopy, initContent bool, f output.Format) (*Pag
 
 // copy creates a copy of this PageOutput with the lazy sync.Once vars reset
 // so they will be evaluated again, for word count calculations etc.
+// The copy gets its own paginator, as it is tied to the output format.
 func (p *PageOutput) copyWithFormat(f output.Format, initContent bool) (*PageOutput, error) {
-	c, err := newPageOutput(p.Page, true, initContent, f)
+	return newPageOutput(p.Page, true, initContent, f)
+}
+
+func (p *PageOutput) copy() (*PageOutput, error) {
+	c, err := p.copyWithFormat(p.outputFormat, false)
 	if err != nil {
 		return nil, err
 	}
@@ -94,10 +99,6 @@ func (p *PageOutput) copyWithFormat(f output.Format, initContent bool) (*PageOut
 	return c, nil
 }
 
-func (p *PageOutput) copy() (*PageOutput, error) {
-	return p.copyWithFormat(p.outputFormat, false)
-}
-
 func (p *PageOutput) layouts(layouts ...string) ([]string, error) {
 	if len(layouts) == 0 && p.selfLayout != "" {
 		return []string{p.selfLayout}, nil
@@ -115,6 +116,14 @@ func (p *PageOutput) layouts(layouts ...string) ([]string, error) {
 		p.outputFormat)
 }
 
+// LayoutLookupOrder returns the layout templates Hugo looks for, in order,
+// when rendering this page in the current output format. The first one that
+// exists is used. If a layout is given, it is resolved as in .Render.
+// This is useful to debug which template renders a page.
+func (p *PageOutput) LayoutLookupOrder(layout ...string) ([]string, error) {
+	return p.layouts(layout...)
+}
+
 func (p *PageOutput) Render(layout ...string) template.HTML {
 	l, err := p.layouts(layout...)
 	if err != nil {
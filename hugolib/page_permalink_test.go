@@ -98,3 +98,25 @@ Content
 		}
 	}
 }
+
+func TestPermalinkRemovePathAccents(t *testing.T) {
+	t.Parallel()
+
+	for _, enabled := range []bool{true, false} {
+		b := newTestSitesBuilder(t).WithConfigFile("toml", fmt.Sprintf(`
+baseURL = "http://example.com/"
+removePathAccents = %t
+`, enabled))
+
+		b.WithContent("crème-brûlée/café.md", "---\ntitle: Café\n---\n")
+		b.WithTemplatesAdded("_default/single.html", `Single: {{ .Title }}|{{ .RelPermalink }}`)
+
+		b.Build(BuildCfg{})
+
+		if enabled {
+			b.AssertFileContent("public/creme-brulee/cafe/index.html", "Single: Café|/creme-brulee/cafe/")
+		} else {
+			b.AssertFileContent("public/crème-brûlée/café/index.html", "Single: Café|/cr%C3%A8me-br%C3%BBl%C3%A9e/caf%C3%A9/")
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"testing"
+)
+
+func TestRenderHooks(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent("page.md", `---
+title: Page
+---
+[Internal](/about/) and [External](https://gohugo.io "Hugo").
+
+![Alt text](/images/sunset.jpg "Sunset")
+
+{{% inner %}}[Inner](https://example.org){{% /inner %}}
+`)
+
+	b.WithTemplates(
+		"_default/single.html", `Content: {{ .Content }}|RenderString: {{ .RenderString "[RS](https://example.com)" }}|Markdownify: {{ "[MD](https://example.net)" | markdownify }}|MarkdownifyHooks: {{ "[MD](https://example.net)" | markdownify (dict "renderHooks" true) }}|`,
+		"shortcodes/inner.html", `{{ .Inner }}`,
+		"_default/_markup/render-link.html", `<a href="{{ .Destination | safeURL }}"{{ with .Title }} title="{{ . }}"{{ end }}{{ if strings.HasPrefix .Destination "http" }} rel="noopener"{{ end }}>{{ .Text }}</a>{{ with .Page }}[{{ .Title }}]{{ end }}`,
+		"_default/_markup/render-image.html", `<img src="{{ .Destination | safeURL }}" alt="{{ .PlainText }}" title="{{ .Title }}">`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/page/index.html",
+		`<a href="/about/">Internal</a>[Page]`,
+		`<a href="https://gohugo.io" title="Hugo" rel="noopener">External</a>[Page]`,
+		`<img src="/images/sunset.jpg" alt="Alt text" title="Sunset">`,
+		`<a href="https://example.org" rel="noopener">Inner</a>[Page]`,
+		`RenderString: <a href="https://example.com" rel="noopener">RS</a>[Page]|`,
+		`Markdownify: <a href="https://example.net">MD</a>|`,
+		`MarkdownifyHooks: <a href="https://example.net" rel="noopener">MD</a>|`,
+	)
+}
+
+func TestRenderHooksNoTemplates(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent("page.md", `---
+title: Page
+---
+[External](https://gohugo.io) ![Alt](/sunset.jpg)
+`)
+
+	b.WithTemplates("_default/single.html", `Content: {{ .Content }}|`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/page/index.html",
+		`<a href="https://gohugo.io">External</a> <img src="/sunset.jpg" alt="Alt" />`,
+	)
+}
+
+func TestRenderHooksCodeBlock(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent("page.md", "---\ntitle: Page\n---\n"+
+		"```go {linenos=true hl_lines=\"1-2\"}\nfmt.Println(\"<Hugo>\")\n```\n\n"+
+		"```\nplain\n```\n")
+
+	b.WithTemplates(
+		"_default/single.html", `Content: {{ .Content }}|`,
+		"_default/_markup/render-codeblock.html", `<div class="code" data-lang="{{ .Lang }}" data-page="{{ .Page.Title }}"{{ with .Attributes.hl_lines }} data-hl="{{ . }}"{{ end }}{{ with .Attributes.linenos }} data-linenos="{{ . }}"{{ end }}><pre>{{ .Code }}</pre><button>Copy</button></div>`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/page/index.html",
+		`<div class="code" data-lang="go" data-page="Page" data-hl="1-2" data-linenos="true"><pre>fmt.Println(&#34;&lt;Hugo&gt;&#34;)</pre><button>Copy</button></div>`,
+		`<div class="code" data-lang="" data-page="Page"><pre>plain</pre><button>Copy</button></div>`,
+	)
+}
+
+func TestRenderHooksCodeBlockNoTemplate(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent("page.md", "---\ntitle: Page\n---\n"+
+		"```go\nfmt.Println(\"Hugo\")\n```\n")
+
+	b.WithTemplates("_default/single.html", `Content: {{ .Content }}|`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/page/index.html",
+		`<pre><code class="language-go">fmt.Println(&quot;Hugo&quot;)`,
+	)
+}
+
+func TestRenderHooksCodeBlockHighlight(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent("page.md", "---\ntitle: Page\n---\n"+
+		"```go {hl_lines=1 copy=true}\nvar a = 1\n```\n")
+
+	b.WithTemplates(
+		"_default/single.html", `Content: {{ .Content }}|`,
+		"_default/_markup/render-codeblock.html", `<div class="wrap">{{ transform.HighlightCodeBlock . }}</div>`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/page/index.html",
+		`<div class="wrap"><div class="highlight"><pre style=`,
+		`<code class="language-go" data-lang="go"><span style="display:block;width:100%;background-color:#3c3d38"><span style="color:#66d9ef">var</span>`,
+	)
+}
+
+func TestRenderHooksCodeBlockPerLanguage(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent("page.md", "---\ntitle: Page\n---\n"+
+		"```mermaid\ngraph TD;\n    A-->B;\n    A-- \"<b>&</b>\" -->C;\n```\n\n"+
+		"```goat\n+--+\n|  |\n+--+\n```\n\n"+
+		"```go\nvar a = 1\n```\n")
+
+	b.WithTemplates(
+		"_default/single.html", `Content: {{ .Content }}|`,
+		"_default/_markup/render-codeblock-mermaid.html", `<pre class="mermaid">{{ .Code | safeHTML }}</pre>`,
+		"_default/_markup/render-codeblock-goat.html", `<div class="goat" data-lang="{{ .Lang }}">{{ .Code | safeHTML }}</div>`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/page/index.html",
+		"<pre class=\"mermaid\">graph TD;\n    A-->B;\n    A-- \"<b>&</b>\" -->C;</pre>",
+		"<div class=\"goat\" data-lang=\"goat\">+--+\n|  |\n+--+</div>",
+		// No hook for Go and no default code block hook.
+		`<pre><code class="language-go">var a = 1`,
+	)
+}
@@ -0,0 +1,143 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/output"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/cast"
+)
+
+var (
+	paragraphTrimPrefix = []byte("<p>")
+	paragraphTrimSuffix = []byte("</p>\n")
+	paragraphIndicator  = []byte("<p")
+)
+
+// renderStringOpts configures RenderString.
+type renderStringOpts struct {
+	// The markup to use, e.g. "markdown". Default is the page's markup.
+	Markup string
+
+	// "inline" (default) or "block". When inline, a single paragraph is
+	// rendered without the wrapping p element.
+	Display string
+
+	// Whether to execute any shortcodes in the string. Default is false.
+	Shortcodes bool
+}
+
+// RenderString renders the given markup string with the page's rendering
+// configuration. An options map can be given as the first argument:
+//
+//	{{ .RenderString "**bold**" }}
+//	{{ .RenderString (dict "display" "block" "markup" "markdown") "**bold**" }}
+//	{{ .RenderString (dict "shortcodes" true) "{{< myshortcode >}}" }}
+func (p *Page) RenderString(args ...interface{}) (template.HTML, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return "", errors.New("want 1 or 2 arguments")
+	}
+
+	var opts renderStringOpts
+
+	if len(args) == 2 {
+		m, err := cast.ToStringMapE(args[0])
+		if err != nil {
+			return "", fmt.Errorf("invalid options: %s", err)
+		}
+		if err := mapstructure.WeakDecode(m, &opts); err != nil {
+			return "", fmt.Errorf("invalid options: %s", err)
+		}
+	}
+
+	s, err := cast.ToStringE(args[len(args)-1])
+	if err != nil {
+		return "", err
+	}
+
+	switch opts.Display {
+	case "", "inline", "block":
+	default:
+		return "", fmt.Errorf("invalid display %q, must be one of inline or block", opts.Display)
+	}
+
+	markup := p.Markup
+	if opts.Markup != "" {
+		markup = helpers.GuessType(opts.Markup)
+		if markup == "unknown" {
+			return "", fmt.Errorf("unknown markup %q", opts.Markup)
+		}
+	}
+	if markup == "" || markup == "unknown" {
+		markup = "markdown"
+	}
+
+	var shortcodes *shortcodeHandler
+	if opts.Shortcodes {
+		shortcodes = newShortcodeHandler(p)
+		s, err = shortcodes.extractShortcodes(s, p.withoutContent())
+		if err != nil {
+			return "", err
+		}
+	}
+
+	b := p.s.ContentSpec.RenderBytes(&helpers.RenderingContext{
+		Content: []byte(s), PageFmt: markup,
+		Cfg:        p.Language(),
+		DocumentID: p.UniqueID(), DocumentName: p.Path(),
+		Config: p.getRenderingConfig(), RenderHooks: p.renderHooks()})
+
+	if opts.Display != "block" {
+		// Strip if this is a short inline type of text.
+		first := bytes.Index(b, paragraphIndicator)
+		last := bytes.LastIndex(b, paragraphIndicator)
+		if first == last {
+			b = bytes.TrimPrefix(b, paragraphTrimPrefix)
+			b = bytes.TrimSuffix(b, paragraphTrimSuffix)
+		}
+	}
+
+	if shortcodes != nil {
+		b, err = shortcodes.renderInto(b)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return template.HTML(b), nil
+}
+
+// renderInto renders the shortcodes extracted into this handler for the
+// current output format and replaces their placeholders in b.
+func (s *shortcodeHandler) renderInto(b []byte) ([]byte, error) {
+	f := output.HTMLFormat
+	if s.p.s.rc != nil {
+		f = s.p.s.rc.Format
+	}
+
+	rendered := make(map[string]string)
+	for _, k := range s.shortcodes.Keys() {
+		placeholder := k.(string)
+		key := newScKeyFromLangAndOutputFormat(s.p.Lang(), f, placeholder)
+		rendered[placeholder] = renderShortcode(key, s.shortcodes.getShortcode(k), nil, s.p)
+	}
+
+	return replaceShortcodeTokens(b, shortcodePlaceholderPrefix, rendered)
+}
@@ -0,0 +1,121 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderString(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent("page.md", `---
+title: Page
+---
+`)
+	b.WithData("intro.toml", `text = "Some **intro** text."`)
+
+	b.WithTemplates("_default/single.html", `
+Inline: {{ .RenderString "**bold**" }}|
+Data: {{ .RenderString .Site.Data.intro.text }}|
+Block: {{ .RenderString (dict "display" "block") "**bold**" }}|
+Paragraphs: {{ .RenderString "P1\n\nP2" }}|
+HTML: {{ .RenderString (dict "markup" "html") "<b>html</b>" }}|
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/page/index.html",
+		"Inline: <strong>bold</strong>|",
+		"Data: Some <strong>intro</strong> text.|",
+		"Block: <p><strong>bold</strong></p>\n|",
+		"Paragraphs: <p>P1</p>\n\n<p>P2</p>\n|",
+		"HTML: <b>html</b>|",
+	)
+}
+
+func TestRenderStringInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+	b.WithContent("page.md", `---
+title: Page
+---
+`)
+	b.CreateSites()
+	b.Build(BuildCfg{SkipRender: true})
+
+	p := b.H.Sites[0].RegularPages[0]
+
+	for _, args := range [][]interface{}{
+		{},
+		{map[string]interface{}{"display": "none"}, "**bold**"},
+		{map[string]interface{}{"markup": "foo"}, "**bold**"},
+		{"not a map", "**bold**"},
+	} {
+		if _, err := p.RenderString(args...); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
+
+func TestRenderStringWithShortcodes(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent("page.md", `---
+title: Page
+---
+`)
+
+	b.WithTemplates(
+		"shortcodes/hello.html", `Hello {{ .Get 0 }} from {{ .Page.Title }}!`,
+		"shortcodes/box.html", `<div class="box">{{ .Inner }}</div>`,
+		"_default/single.html", `
+Shortcode: {{ .RenderString (dict "shortcodes" true) "**Greeting**: {{< hello world >}}" }}|
+Inner: {{ .RenderString (dict "shortcodes" true "display" "block") "{{% box %}}*inner*{{% /box %}}" }}|
+No shortcodes: {{ .RenderString "{{< hello world >}}" }}|
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/page/index.html",
+		"Shortcode: <strong>Greeting</strong>: Hello world from Page!|",
+		"Inner: <div class=\"box\"><em>inner</em></div>\n|",
+		"No shortcodes: {{&lt; hello world &gt;}}|",
+	)
+}
+
+func TestRenderStringWithUndefinedShortcode(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+	b.WithContent("page.md", `---
+title: Page
+---
+`)
+	b.CreateSites()
+	b.Build(BuildCfg{SkipRender: true})
+
+	p := b.H.Sites[0].RegularPages[0]
+
+	_, err := p.RenderString(map[string]interface{}{"shortcodes": true}, "{{< nope >}}")
+	if err == nil || !strings.Contains(err.Error(), `Unable to locate template for shortcode "nope"`) {
+		t.Fatalf("got %v", err)
+	}
+}
@@ -1002,6 +1002,115 @@ Content
 
 }
 
+func TestPageWithFrontMatterConfigOrder(t *testing.T) {
+	t.Parallel()
+
+	pageContent := `---
+title: Page
+date: 2017-01-01
+lastmod: 2017-02-01
+publishDate: 2017-03-01
+---
+Content
+`
+
+	for _, test := range []struct {
+		name        string
+		frontmatter map[string]interface{}
+		date        string
+		lastmod     string
+		publishDate string
+	}{
+		{"default", nil, "2017-01-01", "2017-02-01", "2017-03-01"},
+		{"filename first", map[string]interface{}{
+			"date":        []string{":filename", ":default"},
+			"lastmod":     []string{":filename", "lastmod"},
+			"publishDate": []string{":filename", "publishDate"},
+		}, "2012-02-21", "2012-02-21", "2012-02-21"},
+		{"filename last", map[string]interface{}{
+			"date": []string{"date", ":filename"},
+		}, "2017-01-01", "2017-02-01", "2017-03-01"},
+		{"custom order", map[string]interface{}{
+			"date":        []string{"lastmod", "date"},
+			"lastmod":     []string{"publishDate"},
+			"publishDate": []string{":filename"},
+		}, "2017-02-01", "2017-03-01", "2012-02-21"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assrt := require.New(t)
+			cfg, fs := newTestCfg()
+
+			if test.frontmatter != nil {
+				cfg.Set("frontmatter", test.frontmatter)
+			}
+
+			writeSource(t, fs, filepath.Join("content", "section", "2012-02-21-page.md"), pageContent)
+
+			s := buildSingleSite(t, deps.DepsCfg{Fs: fs, Cfg: cfg}, BuildCfg{SkipRender: true})
+
+			assrt.Len(s.RegularPages, 1)
+			p := s.RegularPages[0]
+
+			assrt.Equal(test.date, p.Date.Format("2006-01-02"), "date")
+			assrt.Equal(test.lastmod, p.Lastmod.Format("2006-01-02"), "lastmod")
+			assrt.Equal(test.publishDate, p.PublishDate.Format("2006-01-02"), "publishDate")
+		})
+	}
+}
+
+func TestPageWithFilenameDatePatterns(t *testing.T) {
+	t.Parallel()
+
+	assrt := require.New(t)
+	cfg, fs := newTestCfg()
+
+	cfg.Set("frontmatter", map[string]interface{}{
+		"date":             []string{":filename", ":default"},
+		"filenamePatterns": []string{":default", `^(?P<slug>.+)\.(?P<date>\d{4}-\d{2}-\d{2})$`},
+	})
+
+	writeSource(t, fs, filepath.Join("content", "sect", "2021-05-06-first.md"), "---\ntitle: First\n---\n")
+	writeSource(t, fs, filepath.Join("content", "sect", "second.2021-05-07.md"), "---\ntitle: Second\n---\n")
+
+	s := buildSingleSite(t, deps.DepsCfg{Fs: fs, Cfg: cfg}, BuildCfg{SkipRender: true})
+
+	first := s.getPage(KindPage, "sect/2021-05-06-first.md")
+	second := s.getPage(KindPage, "sect/second.2021-05-07.md")
+	assrt.NotNil(first)
+	assrt.NotNil(second)
+
+	assrt.Equal("2021-05-06", first.Date.Format("2006-01-02"))
+	assrt.Equal("first", first.Slug)
+	assrt.Equal("2021-05-07", second.Date.Format("2006-01-02"))
+	assrt.Equal("second", second.Slug)
+}
+
+func TestPageWithEmoji(t *testing.T) {
+	t.Parallel()
+
+	for _, enabled := range []bool{true, false} {
+		b := newTestSitesBuilder(t)
+		b.WithConfigFile("toml", fmt.Sprintf(`
+baseURL = "http://example.com/"
+enableEmoji = %t
+`, enabled))
+		b.WithContent("p1.md", `---
+title: "Hello :smile:"
+---
+A :beer: and a :no_such_emoji:.
+`)
+		b.WithTemplatesAdded("_default/single.html", `Title: {{ .Title | emojify }}|Content: {{ .Content }}`)
+		b.CreateSites().Build(BuildCfg{})
+
+		// The emojify template func does not depend on enableEmoji.
+		if enabled {
+			b.AssertFileContent("public/p1/index.html", "Title: Hello 😄|", "<p>A 🍺 and a :no_such_emoji:.</p>")
+		} else {
+			b.AssertFileContent("public/p1/index.html", "Title: Hello 😄|", "<p>A :beer: and a :no_such_emoji:.</p>")
+		}
+	}
+}
+
 func TestWordCountWithAllCJKRunesWithoutHasCJKLanguage(t *testing.T) {
 	t.Parallel()
 	assertFunc := func(t *testing.T, ext string, pages Pages) {
@@ -1498,6 +1607,130 @@ social:
 	assert.Nil(t, nonexistentKeyValue)
 }
 
+func TestPageParamsDeepMerge(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+[params]
+rating = "site"
+author = "site author"
+[params.social]
+twitter = "@site"
+facebook = "site-facebook"
+[params.social.github]
+user = "site-user"
+repo = "site-repo"
+`)
+
+	b.WithContent("p1.md", `---
+title: P1
+author:
+  name: page author
+social:
+  twitter: "@page"
+  github:
+    repo: page-repo
+---
+`)
+
+	b.WithTemplatesAdded("_default/single.html", `
+{{ $social := .Param "social" }}
+Twitter: {{ $social.twitter }}|Facebook: {{ $social.facebook }}
+GitHub: {{ .Param "social.github.user" }}|{{ .Param "social.github.repo" }}|{{ (.Param "social.github").user }}
+Author: {{ (.Param "author").name }}
+Rating: {{ .Param "rating" }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/p1/index.html",
+		"Twitter: @page|Facebook: site-facebook",
+		"GitHub: site-user|page-repo|site-user",
+		"Author: page author",
+		"Rating: site",
+	)
+}
+
+func TestPageParamsNestedSiteOnly(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+[params]
+[params.author]
+name = "site author"
+[params.author.social]
+twitter = "@site"
+[params.Mixed.Case]
+Key = "mixed"
+`)
+
+	b.WithContent("p1.md", `---
+title: P1
+author:
+  email: page@example.com
+---
+`, "p2.md", `---
+title: P2
+author: not a map
+---
+`)
+
+	b.WithTemplatesAdded("_default/single.html", `
+Page: {{ .Param "author.name" }}|{{ .Param "author.social.twitter" }}|{{ .Param "author.email" }}|{{ .Param "mixed.case.key" }}|{{ .Param "author.social.missing" }}|
+Site: {{ .Site.Param "author.name" }}|{{ .Site.Param "Author.Social.Twitter" }}|{{ .Site.Param "author.missing" }}|{{ .Site.Param "missing.key" }}|
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/p1/index.html",
+		"Page: site author|@site|page@example.com|mixed||",
+		"Site: site author|@site|||",
+	)
+
+	b.AssertFileContent("public/p2/index.html",
+		"Page: site author|@site||mixed||",
+	)
+}
+
+func TestPageKeywords(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSite(t)
+
+	for i, test := range []struct {
+		frontMatter string
+		expect      []string
+	}{
+		{"title: None", []string{}},
+		{"keywords: [hugo, go]", []string{"hugo", "go"}},
+		{"tags: [web, go]", []string{"web", "go"}},
+		{"keywords: [hugo, Go, ' ', hugo]\ntags: [web, go, GO, static]", []string{"hugo", "Go", "web", "static"}},
+	} {
+		p, err := s.NewPageFrom(strings.NewReader("---\n"+test.frontMatter+"\n---\n"), "content/post/p.md")
+		require.NoError(t, err)
+		require.Equal(t, test.expect, p.Keywords(), "[%d]", i)
+	}
+}
+
+func TestPageKeywordSources(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSite(t, "keywordSources", []string{"categories", "keywords"})
+
+	p, err := s.NewPageFrom(strings.NewReader(`---
+keywords: [hugo, go]
+tags: [web]
+categories: [Go, docs]
+---
+`), "content/post/p.md")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Go", "docs", "hugo"}, p.Keywords())
+}
+
 func TestPageSimpleMethods(t *testing.T) {
 	t.Parallel()
 	s := newTestSite(t)
@@ -1905,3 +2138,65 @@ func TestRawAlias(t *testing.T) {
 		t.Errorf("Raw output is not what we expected: %s", renderedRawContent)
 	}
 }
+
+func TestRawContentAndPlainAcrossMarkups(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent(
+		"p1.md", "---\ntitle: P1\n---\n\nSome **bold** text &amp; <em>more</em>.\n",
+		"p2.org", "#+TITLE: P2\n\nSome *bold* text & /more/.\n",
+	)
+
+	b.WithTemplatesAdded("_default/single.html", `Raw: {{ .RawContent | safeHTML }}|Plain: {{ .Plain | safeHTML }}|`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/p1/index.html",
+		"Raw: Some **bold** text &amp; <em>more</em>.\n|",
+		"Plain: Some bold text & more.\n|")
+	b.AssertFileContent("public/p2/index.html",
+		"Raw: Some *bold* text & /more/.\n|",
+		"Plain: Some bold text & more.\n|")
+}
+
+func TestPlainWithEscapedLessThan(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent("p1.md", "---\ntitle: P1\n---\n\nIf a < b then the rest of this sentence matters. Second sentence here.\n")
+
+	b.WithTemplatesAdded("_default/single.html", `Plain: {{ .Plain | safeHTML }}|Words: {{ delimit .PlainWords "," | safeHTML }}|Count: {{ .WordCount }}|Summary: {{ .Summary }}|`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/p1/index.html",
+		"Plain: If a < b then the rest of this sentence matters. Second sentence here.\n|",
+		"Words: If,a,<,b,then,the,rest,of,this,sentence,matters.,Second,sentence,here.|",
+		"Count: 14|",
+		"Summary: If a &lt; b then the rest of this sentence matters. Second sentence here.|")
+}
+
+func TestPageLen(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent(
+		"p1.md", "---\ntitle: P1\n---\nHello **world**.\n",
+		"p2.md", "---\ntitle: P2\n---\n好\n",
+		"p3.md", "---\ntitle: P3\n---\n",
+	)
+
+	b.WithTemplatesAdded("_default/single.html", `Len: {{ .Len }}|{{ len .Content }}|`)
+
+	b.Build(BuildCfg{})
+
+	// <p>Hello <strong>world</strong>.</p>\n
+	b.AssertFileContent("public/p1/index.html", "Len: 37|37|")
+	// The length is in bytes, not runes.
+	b.AssertFileContent("public/p2/index.html", "Len: 11|11|")
+	b.AssertFileContent("public/p3/index.html", "Len: 0|0|")
+}
@@ -14,9 +14,11 @@
 package pagemeta
 
 import (
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -141,6 +143,58 @@ func dateAndSlugFromBaseFilename(name string) (time.Time, string) {
 	return d, slug
 }
 
+// filenameDateMatcher extracts a date and a slug from a content file's base
+// filename. A zero date means no match.
+type filenameDateMatcher func(name string) (time.Time, string)
+
+// newFilenameDatePatternMatcher creates a filenameDateMatcher from a regular
+// expression with a named group "date" holding a date on the form 2006-01-02,
+// and an optional named group "slug". It is matched against the filename,
+// and then against the filename without its extension.
+func newFilenameDatePatternMatcher(pattern string) (filenameDateMatcher, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filename date pattern %q: %s", pattern, err)
+	}
+
+	dateIdx, slugIdx := -1, -1
+	for i, name := range re.SubexpNames() {
+		switch name {
+		case "date":
+			dateIdx = i
+		case "slug":
+			slugIdx = i
+		}
+	}
+
+	if dateIdx == -1 {
+		return nil, fmt.Errorf("invalid filename date pattern %q: missing date group, e.g. (?P<date>\\d{4}-\\d{2}-\\d{2})", pattern)
+	}
+
+	return func(name string) (time.Time, string) {
+		m := re.FindStringSubmatch(name)
+		if m == nil {
+			withoutExt, _ := helpers.FileAndExt(name)
+			m = re.FindStringSubmatch(withoutExt)
+		}
+		if m == nil {
+			return time.Time{}, ""
+		}
+
+		d, err := time.Parse("2006-01-02", m[dateIdx])
+		if err != nil {
+			return time.Time{}, ""
+		}
+
+		var slug string
+		if slugIdx != -1 {
+			slug = strings.Trim(m[slugIdx], " -_.")
+		}
+
+		return d, slug
+	}, nil
+}
+
 type frontMatterFieldHandler func(d *FrontMatterDescriptor) (bool, error)
 
 func (f FrontMatterHandler) newChainedFrontMatterFieldHandler(handlers ...frontMatterFieldHandler) frontMatterFieldHandler {
@@ -163,6 +217,9 @@ type frontmatterConfig struct {
 	lastmod     []string
 	publishDate []string
 	expiryDate  []string
+
+	// Used by :filename, tried in order.
+	filenameDates []filenameDateMatcher
 }
 
 const (
@@ -173,6 +230,9 @@ const (
 	fmLastmod    = "lastmod"
 	fmExpiryDate = "expirydate"
 
+	// The patterns used by :filename.
+	fmFilenamePatterns = "filenamepatterns"
+
 	// Gets date from filename, e.g 218-02-22-mypage.md
 	fmFilename = ":filename"
 
@@ -190,6 +250,8 @@ func newDefaultFrontmatterConfig() frontmatterConfig {
 		lastmod:     []string{fmGitAuthorDate, fmLastmod, fmDate, fmPubDate},
 		publishDate: []string{fmPubDate, fmDate},
 		expiryDate:  []string{fmExpiryDate},
+
+		filenameDates: []filenameDateMatcher{dateAndSlugFromBaseFilename},
 	}
 }
 
@@ -211,6 +273,12 @@ func newFrontmatterConfig(cfg config.Provider) (frontmatterConfig, error) {
 					c.lastmod = toLowerSlice(v)
 				case fmExpiryDate:
 					c.expiryDate = toLowerSlice(v)
+				case fmFilenamePatterns:
+					matchers, err := newFilenameDateMatchers(cast.ToStringSlice(v))
+					if err != nil {
+						return c, err
+					}
+					c.filenameDates = matchers
 				}
 			}
 		}
@@ -230,6 +298,25 @@ func newFrontmatterConfig(cfg config.Provider) (frontmatterConfig, error) {
 	return c, nil
 }
 
+// newFilenameDateMatchers creates the matchers for the given patterns. The
+// special ":default" pattern matches dates prefixing the filename, e.g.
+// 2018-02-22-mypage.md.
+func newFilenameDateMatchers(patterns []string) ([]filenameDateMatcher, error) {
+	var matchers []filenameDateMatcher
+	for _, pattern := range patterns {
+		if pattern == ":default" {
+			matchers = append(matchers, dateAndSlugFromBaseFilename)
+			continue
+		}
+		m, err := newFilenameDatePatternMatcher(pattern)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
 func addDateFieldAliases(values []string) []string {
 	var complete []string
 
@@ -351,7 +438,7 @@ func (f FrontMatterHandler) createDateHandler(identifiers []string, setter func(
 	for _, identifier := range identifiers {
 		switch identifier {
 		case fmFilename:
-			handlers = append(handlers, h.newDateFilenameHandler(setter))
+			handlers = append(handlers, h.newDateFilenameHandler(f.fmConfig.filenameDates, setter))
 		case fmModTime:
 			handlers = append(handlers, h.newDateModTimeHandler(setter))
 		case fmGitAuthorDate:
@@ -391,9 +478,17 @@ func (f *frontmatterFieldHandlers) newDateFieldHandler(key string, setter func(d
 	}
 }
 
-func (f *frontmatterFieldHandlers) newDateFilenameHandler(setter func(d *FrontMatterDescriptor, t time.Time)) frontMatterFieldHandler {
+func (f *frontmatterFieldHandlers) newDateFilenameHandler(matchers []filenameDateMatcher, setter func(d *FrontMatterDescriptor, t time.Time)) frontMatterFieldHandler {
 	return func(d *FrontMatterDescriptor) (bool, error) {
-		date, slug := dateAndSlugFromBaseFilename(d.BaseFilename)
+		var (
+			date time.Time
+			slug string
+		)
+		for _, match := range matchers {
+			if date, slug = match(d.BaseFilename); !date.IsZero() {
+				break
+			}
+		}
 		if date.IsZero() {
 			return false, nil
 		}
@@ -62,6 +62,71 @@ func TestDateAndSlugFromBaseFilename(t *testing.T) {
 	}
 }
 
+func TestFrontMatterFilenamePatterns(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	cfg := viper.New()
+	cfg.Set("frontmatter", map[string]interface{}{
+		"date": []string{":filename", ":default"},
+		"filenamePatterns": []string{
+			":default",
+			`^(?P<slug>.+)\.(?P<date>\d{4}-\d{2}-\d{2})$`,
+		},
+	})
+
+	handler, err := NewFrontmatterHandler(nil, cfg)
+	assert.NoError(err)
+
+	for i, test := range []struct {
+		name string
+		date string
+		slug string
+	}{
+		{"2021-05-06-title.md", "2021-05-06", "title"},
+		{"title.2021-05-07.md", "2021-05-07", "title"},
+		{"my-title.2021-05-08.md", "2021-05-08", "my-title"},
+		{"title.md", "0001-01-01", ""},
+		{"title.2021-13-01.md", "0001-01-01", ""},
+	} {
+		errMsg := fmt.Sprintf("Test %d", i)
+
+		d := newTestFd()
+		d.BaseFilename = test.name
+		assert.NoError(handler.HandleDates(d), errMsg)
+
+		assert.Equal(test.date, d.Dates.Date.Format("2006-01-02"), errMsg)
+		assert.Equal(test.slug, d.PageURLs.Slug, errMsg)
+	}
+
+	// Without :default, only the configured patterns are used.
+	cfg.Set("frontmatter", map[string]interface{}{
+		"date":             []string{":filename"},
+		"filenamePatterns": []string{`^(?P<date>\d{4}-\d{2}-\d{2})$`},
+	})
+	handler, err = NewFrontmatterHandler(nil, cfg)
+	assert.NoError(err)
+
+	d := newTestFd()
+	d.BaseFilename = "2021-05-06-title.md"
+	assert.NoError(handler.HandleDates(d))
+	assert.True(d.Dates.Date.IsZero())
+
+	d = newTestFd()
+	d.BaseFilename = "2021-05-06.md"
+	assert.NoError(handler.HandleDates(d))
+	assert.Equal("2021-05-06", d.Dates.Date.Format("2006-01-02"))
+
+	for _, pattern := range []string{`(?P<date>[`, `^(?P<slug>.+)$`} {
+		cfg.Set("frontmatter", map[string]interface{}{
+			"filenamePatterns": []string{pattern},
+		})
+		_, err = NewFrontmatterHandler(nil, cfg)
+		assert.Error(err, pattern)
+	}
+}
+
 func newTestFd() *FrontMatterDescriptor {
 	return &FrontMatterDescriptor{
 		Frontmatter: make(map[string]interface{}),
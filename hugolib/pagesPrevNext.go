@@ -40,3 +40,40 @@ func (p Pages) Next(cur *Page) *Page {
 	}
 	return nil
 }
+
+// PagePosition holds the pages before and after a page in a page collection.
+type PagePosition struct {
+	Prev *Page
+	Next *Page
+}
+
+// Position returns the previous and next page relative to p in the given
+// collection, e.g. the pages in a taxonomy:
+//
+//	{{ with (.Position .Site.Taxonomies.tags.hugo).Next }}{{ .Title }}{{ end }}
+//
+// Unlike Pages.Prev and Pages.Next, it does not wrap around, so Prev is nil
+// for the first page and Next is nil for the last page. Both are nil if p is
+// not in the collection.
+func (p *Page) Position(seq interface{}) (PagePosition, error) {
+	var pos PagePosition
+
+	pages, err := toPages(seq)
+	if err != nil {
+		return pos, err
+	}
+
+	for i, c := range pages {
+		if c.Eq(p) {
+			if i > 0 {
+				pos.Prev = pages[i-1]
+			}
+			if i < len(pages)-1 {
+				pos.Next = pages[i+1]
+			}
+			break
+		}
+	}
+
+	return pos, nil
+}
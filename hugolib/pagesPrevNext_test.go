@@ -14,10 +14,12 @@
 package hugolib
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/spf13/cast"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type pagePNTestObject struct {
@@ -50,6 +52,45 @@ func TestNext(t *testing.T) {
 	assert.Equal(t, pages.Next(pages[4]), pages[0])
 }
 
+func TestPagePosition(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	pages := preparePageGroupTestPages(t)
+
+	// A filtered set with every other page.
+	filtered := Pages{pages[0], pages[2], pages[4]}
+
+	pos, err := pages[2].Position(filtered)
+	assert.NoError(err)
+	assert.Equal(pages[0], pos.Prev)
+	assert.Equal(pages[4], pos.Next)
+
+	pos, err = pages[0].Position(filtered)
+	assert.NoError(err)
+	assert.Nil(pos.Prev)
+	assert.Equal(pages[2], pos.Next)
+
+	pos, err = pages[4].Position(&filtered)
+	assert.NoError(err)
+	assert.Equal(pages[2], pos.Prev)
+	assert.Nil(pos.Next)
+
+	// Not in the set.
+	pos, err = pages[1].Position(filtered)
+	assert.NoError(err)
+	assert.Nil(pos.Prev)
+	assert.Nil(pos.Next)
+
+	w := WeightedPages{{Weight: 1, Page: pages[3]}, {Weight: 2, Page: pages[1]}}
+	pos, err = pages[1].Position(w)
+	assert.NoError(err)
+	assert.Equal(pages[3], pos.Prev)
+	assert.Nil(pos.Next)
+
+	_, err = pages[1].Position("foo")
+	assert.Error(err)
+}
+
 func prepareWeightedPagesPrevNext(t *testing.T) WeightedPages {
 	s := newTestSite(t)
 	w := WeightedPages{}
@@ -84,3 +125,27 @@ func TestWeightedPagesNext(t *testing.T) {
 	assert.Equal(t, w.Next(w[1].Page), w[2].Page)
 	assert.Equal(t, w.Next(w[4].Page), w[0].Page)
 }
+
+func TestPagePositionInTemplate(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	for i, tags := range []string{"a", "b", "a", "a"} {
+		b.WithContent(fmt.Sprintf("p%d.md", i+1), fmt.Sprintf(`---
+title: P%d
+weight: %d
+tags: [%s]
+---
+`, i+1, i+1, tags))
+	}
+
+	b.WithTemplates("_default/single.html", `{{ $pos := .Position .Site.Taxonomies.tags.a }}Page: {{ .Title }}|Next: {{ with .Next }}{{ .Title }}{{ end }}|PosPrev: {{ with $pos.Prev }}{{ .Title }}{{ end }}|PosNext: {{ with $pos.Next }}{{ .Title }}{{ end }}|`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/p1/index.html", "Page: P1|Next: P2|PosPrev: |PosNext: P3|")
+	b.AssertFileContent("public/p3/index.html", "Page: P3|Next: P4|PosPrev: P1|PosNext: P4|")
+	b.AssertFileContent("public/p4/index.html", "Page: P4|Next: |PosPrev: P3|PosNext: |")
+	b.AssertFileContent("public/p2/index.html", "Page: P2|Next: P3|PosPrev: |PosNext: |")
+}
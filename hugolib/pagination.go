@@ -20,8 +20,10 @@ import (
 	"math"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/gohugoio/hugo/config"
+	"github.com/gohugoio/hugo/output"
 
 	"github.com/spf13/cast"
 )
@@ -187,6 +189,45 @@ func (p *Pager) Last() *Pager {
 	return p.pagers[len(p.pagers)-1]
 }
 
+// Window returns the pagers within the given distance of the current page,
+// e.g. pages 3 to 7 for page 5 and a distance of 2.
+// This can be used to build a numbered pager, see HasLeadingEllipsis and
+// HasTrailingEllipsis.
+func (p *Pager) Window(distance int) pagers {
+	low, high := p.windowBounds(distance)
+	return p.pagers[low-1 : high]
+}
+
+// HasLeadingEllipsis returns whether there are pages between the first page
+// and the window with the given distance, see Window.
+func (p *Pager) HasLeadingEllipsis(distance int) bool {
+	low, _ := p.windowBounds(distance)
+	return low > 2
+}
+
+// HasTrailingEllipsis returns whether there are pages between the window
+// with the given distance and the last page, see Window.
+func (p *Pager) HasTrailingEllipsis(distance int) bool {
+	_, high := p.windowBounds(distance)
+	return high < len(p.pagers)-1
+}
+
+// windowBounds returns the first and last page number in the window.
+func (p *Pager) windowBounds(distance int) (int, int) {
+	if distance < 0 {
+		distance = 0
+	}
+
+	low, high := p.PageNumber()-distance, p.PageNumber()+distance
+	if low < 1 {
+		low = 1
+	}
+	if high > len(p.pagers) {
+		high = len(p.pagers)
+	}
+	return low, high
+}
+
 // Pagers returns a list of pagers that can be used to build a pagination menu.
 func (p *paginator) Pagers() pagers {
 	return p.pagers
@@ -261,9 +302,45 @@ func splitPageGroups(pageGroups PagesGroup, size int) []paginatedElement {
 	return split
 }
 
-// Paginator get this Page's main output's paginator.
+// Paginator gets a paginator for this Page.
+// Templates rendering a page paginate its PageOutput, so this is invoked
+// when paginating another page, e.g. when ranging over .Sections. The
+// paginator is kept apart from the one of the main output, so it does not
+// collide with the page's own pagination when that page is rendered.
 func (p *Page) Paginator(options ...interface{}) (*Pager, error) {
-	return p.mainPageOutput.Paginator(options...)
+	po, err := p.getPaginatorPageOutput()
+	if err != nil {
+		return nil, err
+	}
+	return po.Paginator(options...)
+}
+
+func (p *Page) getPaginatorPageOutput() (*PageOutput, error) {
+	var err error
+	p.pageInit.paginatorOutputInit.Do(func() {
+		if len(p.outputFormats) == 0 {
+			err = fmt.Errorf("no output formats for page %q", p.pathOrTitle())
+			return
+		}
+		p.paginatorPageOutput, err = newPageOutput(p, false, false, p.outputFormats[0])
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.paginatorPageOutput == nil {
+		return nil, fmt.Errorf("failed to create paginator for page %q", p.pathOrTitle())
+	}
+
+	return p.paginatorPageOutput, nil
+}
+
+// resetPaginatorPageOutput clears the paginator PageOutput so it gets
+// created again on the next rebuild.
+func (p *Page) resetPaginatorPageOutput() {
+	p.paginatorPageOutput = nil
+	p.pageInit.paginatorOutputInit = sync.Once{}
 }
 
 // Paginator gets this PageOutput's paginator if it's already created.
@@ -311,9 +388,14 @@ func (p *PageOutput) Paginator(options ...interface{}) (*Pager, error) {
 	return p.paginator, nil
 }
 
-// Paginate invokes this Page's main output's Paginate method.
+// Paginate paginates the given sequence for this Page.
+// See Paginator for how this relates to the page's own pagination.
 func (p *Page) Paginate(seq interface{}, options ...interface{}) (*Pager, error) {
-	return p.mainPageOutput.Paginate(seq, options...)
+	po, err := p.getPaginatorPageOutput()
+	if err != nil {
+		return nil, err
+	}
+	return po.Paginate(seq, options...)
 }
 
 // Paginate gets this PageOutput's paginator if it's already created.
@@ -532,14 +614,26 @@ func newPaginationURLFactory(d targetPathDescriptor) paginationURLFactory {
 		pathDescriptor := d
 		var rel string
 		if page > 1 {
-			rel = fmt.Sprintf("/%s/%d/", d.PathSpec.PaginatePath, page)
+			rel = fmt.Sprintf("/%s/%d/", paginatePathForFormat(d.PathSpec.PaginatePath, d.Type), page)
 			pathDescriptor.Addends = rel
 		}
 
 		targetPath := createTargetPath(pathDescriptor)
-		targetPath = strings.TrimSuffix(targetPath, d.Type.BaseFilename())
+		// For /index.json etc. we must use the full path.
+		if d.Type.IsHTML {
+			targetPath = strings.TrimSuffix(targetPath, d.Type.BaseFilename())
+		}
 		link := d.PathSpec.PrependBasePath(targetPath)
 		// Note: The targetPath is massaged with MakePathSanitized
 		return d.PathSpec.URLizeFilename(link)
 	}
 }
+
+// paginatePathForFormat returns the path element to use in paginator URLs for the
+// given output format, falling back to the site's paginatePath.
+func paginatePathForFormat(sitePaginatePath string, f output.Format) string {
+	if f.PaginatePath != "" {
+		return f.PaginatePath
+	}
+	return sitePaginatePath
+}
@@ -20,6 +20,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/gohugoio/hugo/deps"
 	"github.com/gohugoio/hugo/output"
 	"github.com/stretchr/testify/require"
@@ -150,6 +151,59 @@ func doTestPages(t *testing.T, paginator *paginator) {
 	require.Equal(t, 5, last.PageNumber())
 }
 
+func TestPagerWindow(t *testing.T) {
+	t.Parallel()
+	s := newTestSite(t)
+	pages := createTestPages(s, 10)
+
+	urlFactory := func(page int) string {
+		return fmt.Sprintf("page/%d/", page)
+	}
+
+	pag, err := newPaginatorFromPages(pages, 1, urlFactory)
+	require.NoError(t, err)
+	require.Equal(t, 10, pag.TotalPages())
+
+	pageNumbers := func(ps pagers) []int {
+		var numbers []int
+		for _, p := range ps {
+			numbers = append(numbers, p.PageNumber())
+		}
+		return numbers
+	}
+
+	for i, test := range []struct {
+		number   int
+		distance int
+		window   []int
+		leading  bool
+		trailing bool
+	}{
+		{5, 2, []int{3, 4, 5, 6, 7}, true, true},
+		{5, 0, []int{5}, true, true},
+		{5, 10, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, false, false},
+		{1, 2, []int{1, 2, 3}, false, true},
+		{3, 2, []int{1, 2, 3, 4, 5}, false, true},
+		{4, 2, []int{2, 3, 4, 5, 6}, false, true},
+		{8, 2, []int{6, 7, 8, 9, 10}, true, false},
+		{10, 2, []int{8, 9, 10}, true, false},
+		{5, -1, []int{5}, true, true},
+	} {
+		errMsg := fmt.Sprintf("[%d] page %d, distance %d", i, test.number, test.distance)
+		pager := pag.Pagers()[test.number-1]
+		require.Equal(t, test.window, pageNumbers(pager.Window(test.distance)), errMsg)
+		require.Equal(t, test.leading, pager.HasLeadingEllipsis(test.distance), errMsg)
+		require.Equal(t, test.trailing, pager.HasTrailingEllipsis(test.distance), errMsg)
+	}
+
+	empty, err := newPaginatorFromPages(createTestPages(s, 0), 5, urlFactory)
+	require.NoError(t, err)
+	first := empty.Pagers()[0]
+	require.Equal(t, []int{1}, pageNumbers(first.Window(2)))
+	require.False(t, first.HasLeadingEllipsis(2))
+	require.False(t, first.HasTrailingEllipsis(2))
+}
+
 func TestPagerNoPages(t *testing.T) {
 	t.Parallel()
 	s := newTestSite(t)
@@ -219,7 +273,7 @@ func TestPaginationURLFactory(t *testing.T) {
 					{"HTML home page 32",
 						targetPathDescriptor{Kind: KindHome, Type: output.HTMLFormat}, "http://example.com/", 32, "/zoo/32/"},
 					{"JSON home page 42",
-						targetPathDescriptor{Kind: KindHome, Type: output.JSONFormat}, "http://example.com/", 42, "/zoo/42/"},
+						targetPathDescriptor{Kind: KindHome, Type: output.JSONFormat}, "http://example.com/", 42, "/zoo/42/index.json"},
 					// Issue #1252
 					{"BaseURL with sub path",
 						targetPathDescriptor{Kind: KindHome, Type: output.HTMLFormat}, "http://example.com/sub/", 999, "/sub/zoo/999/"},
@@ -239,6 +293,7 @@ func TestPaginationURLFactory(t *testing.T) {
 					}
 
 					if uglyURLs {
+						expected = strings.TrimSuffix(expected, test.d.Type.BaseFilename())
 						expected = expected[:len(expected)-1] + "." + test.d.Type.MediaType.Suffix
 					}
 
@@ -577,3 +632,118 @@ func createTestPages(s *Site, num int) Pages {
 
 	return pages
 }
+
+func TestPaginatorInRangeSections(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+paginate = 1
+`)
+	for _, sect := range []string{"s1", "s2"} {
+		for i := 1; i <= 3; i++ {
+			b.WithContentAdded(fmt.Sprintf("%s/p%d.md", sect, i), fmt.Sprintf("---\ntitle: %s-p%d\n---\n", sect, i))
+		}
+	}
+	b.WithTemplatesAdded("index.html", `{{ range .Sections }}{{ .Title }}: {{ .Paginator.TotalPages }}|{{ end }}`)
+	b.WithTemplatesAdded("_default/list.html", `{{ $pag := .Paginate .Pages.ByTitle 2 }}Total: {{ $pag.TotalPages }}|{{ range $pag.Pages }}{{ .Title }}|{{ end }}`)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "S1s: 3|S2s: 3|")
+	for _, sect := range []string{"s1", "s2"} {
+		b.AssertFileContent(fmt.Sprintf("public/%s/index.html", sect), fmt.Sprintf("Total: 2|%s-p1|%s-p2|", sect, sect))
+		b.AssertFileContent(fmt.Sprintf("public/%s/page/2/index.html", sect), fmt.Sprintf("Total: 2|%s-p3|", sect))
+	}
+}
+
+func TestPaginatePathPerOutputFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, this := range []struct {
+		config  string
+		segment string
+	}{
+		{`paginatePath = "side"`, "side"},
+		{`paginatePath = "side"
+[outputFormats.HTML]
+paginatePath = "seite"`, "seite"},
+	} {
+		b := newTestSitesBuilder(t)
+		b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+paginate = 1
+`+this.config)
+		for i := 1; i <= 3; i++ {
+			b.WithContentAdded(fmt.Sprintf("blog/p%d.md", i), fmt.Sprintf("---\ntitle: p%d\n---\n", i))
+		}
+		b.WithTemplatesAdded("_default/list.html", `Next: {{ with .Paginator.Next }}{{ .URL }}{{ end }}`)
+		b.Build(BuildCfg{})
+
+		b.AssertFileContent("public/blog/index.html", fmt.Sprintf("Next: /blog/%s/2/", this.segment))
+		b.AssertFileContent(fmt.Sprintf("public/blog/%s/2/index.html", this.segment), fmt.Sprintf("Next: /blog/%s/3/", this.segment))
+	}
+}
+
+func TestPaginateJSONOutputFormat(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+paginate = 2
+[outputs]
+home = ["HTML", "JSON", "CSV"]
+[outputFormats.JSON]
+paginate = true
+`)
+	var content []string
+	for i := 1; i <= 5; i++ {
+		content = append(content, fmt.Sprintf("blog/p%d.md", i), fmt.Sprintf("---\ntitle: p%d\nweight: %d\n---\nSummary %d.\n", i, i, i))
+	}
+	b.WithContent(content...)
+	b.WithTemplatesAdded("index.html", `HTML: {{ .Paginator.PageNumber }}|{{ range .Paginator.Pages }}{{ .Title }}|{{ end }}`)
+	b.WithTemplatesAdded("index.csv", `CSV: {{ .Paginator.PageNumber }}`)
+	b.WithTemplatesAdded("index.json", `{"page": {{ .Paginator.PageNumber }}, "next": {{ with .Paginator.Next }}{{ .URL | jsonify }}{{ else }}null{{ end }}, "items": [{{ range $i, $p := .Paginator.Pages }}{{ if $i }}, {{ end }}{"title": {{ .Title | jsonify }}, "summary": {{ .Summary | plainify | jsonify }}, "url": {{ .Permalink | jsonify }}}{{ end }}]}`)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "HTML: 1|p1|p2|")
+	b.AssertFileContent("public/page/2/index.html", "HTML: 2|p3|p4|")
+
+	b.AssertFileContent("public/index.json",
+		`{"page": 1, "next": "/page/2/index.json", "items": [{"title": "p1", "summary": "Summary 1.", "url": "http://example.com/blog/p1/"}, {"title": "p2"`)
+	b.AssertFileContent("public/page/2/index.json",
+		`{"page": 2, "next": "/page/3/index.json", "items": [{"title": "p3"`, `{"title": "p4"`)
+	b.AssertFileContent("public/page/3/index.json",
+		`{"page": 3, "next": null, "items": [{"title": "p5", "summary": "Summary 5.", "url": "http://example.com/blog/p5/"}]}`)
+
+	require.False(t, b.CheckExists("public/page/1/index.json"))
+	require.True(t, b.CheckExists("public/page/1/index.html"))
+
+	// CSV is not in the HTML family and is not set to paginate.
+	b.AssertFileContent("public/index.csv", "CSV: 1")
+	require.False(t, b.CheckExists("public/page/2/index.csv"))
+}
+
+func TestPaginatorInRangeSectionsRebuild(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t).Running()
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+paginate = 1
+`)
+	b.WithContent("s1/_index.md", "---\ntitle: S1s\n---\n", "s1/p1.md", "---\ntitle: p1\n---\n", "s1/p2.md", "---\ntitle: p2\n---\n")
+	b.WithTemplatesAdded("index.html", `{{ range .Sections }}{{ .Title }}: {{ .Paginator.TotalPages }}|{{ end }}`)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "S1s: 2|")
+
+	writeSource(t, b.Fs, filepath.Join("layouts", "index.html"), `{{ range .Sections }}Edited {{ .Title }}: {{ .Paginator.TotalPages }}|{{ end }}`)
+	err := b.H.Build(BuildCfg{}, fsnotify.Event{Name: filepath.FromSlash("layouts/index.html"), Op: fsnotify.Write})
+	assert.NoError(err)
+
+	b.AssertFileContent("public/index.html", "Edited S1s: 2|")
+}
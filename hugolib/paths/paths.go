@@ -55,6 +55,7 @@ type Paths struct {
 
 	DisablePathToLower bool
 	RemovePathAccents  bool
+	TransliteratePaths bool
 	UglyURLs           bool
 	CanonifyURLs       bool
 
@@ -142,6 +143,7 @@ func New(fs *hugofs.Fs, cfg config.Provider) (*Paths, error) {
 
 		DisablePathToLower: cfg.GetBool("disablePathToLower"),
 		RemovePathAccents:  cfg.GetBool("removePathAccents"),
+		TransliteratePaths: cfg.GetBool("transliteratePaths"),
 		UglyURLs:           cfg.GetBool("uglyURLs"),
 		CanonifyURLs:       cfg.GetBool("canonifyURLs"),
 
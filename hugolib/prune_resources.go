@@ -17,15 +17,30 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/gohugoio/hugo/helpers"
 
 	"github.com/spf13/afero"
+	jww "github.com/spf13/jwalterweatherman"
 )
 
-// GC requires a build first.
+// GC removes unused files from the resource cache and stale files from the
+// publish dir, and returns the number of files removed. GC requires a build first.
 func (h *HugoSites) GC() (int, error) {
+	removed, err := h.gc(false)
+	return len(removed), err
+}
+
+// GCDryRun is like GC, but only returns the absolute filenames of the files
+// that would be removed, sorted by name.
+func (h *HugoSites) GCDryRun() ([]string, error) {
+	return h.gc(true)
+}
+
+func (h *HugoSites) gc(dryRun bool) ([]string, error) {
 	s := h.Sites[0]
 	fs := h.PathSpec.BaseFs.Resources.Fs
 
@@ -62,8 +77,8 @@ func (h *HugoSites) GC() (int, error) {
 		return false
 	}
 
-	walker := func(dirname string, inUse func(filename string) bool) (int, error) {
-		counter := 0
+	walker := func(dirname string, inUse func(filename string) bool) ([]string, error) {
+		var removed []string
 		err := afero.Walk(fs, dirname, func(path string, info os.FileInfo, err error) error {
 			if info == nil {
 				return nil
@@ -80,7 +95,7 @@ func (h *HugoSites) GC() (int, error) {
 				}
 				defer f.Close()
 				_, err = f.Readdirnames(1)
-				if err == io.EOF {
+				if err == io.EOF && !dryRun {
 					// Empty dir.
 					s.Fs.Source.Remove(path)
 				}
@@ -88,29 +103,122 @@ func (h *HugoSites) GC() (int, error) {
 				return nil
 			}
 
-			inUse := inUse(path)
-			if !inUse {
-				err := fs.Remove(path)
-				if err != nil && !os.IsNotExist(err) {
-					s.Log.ERROR.Printf("Failed to remove %q: %s", path, err)
-				} else {
-					counter++
-				}
+			if !inUse(path) && removeFile(fs, path, dryRun, s.Log) {
+				removed = append(removed, filepath.Join(h.PathSpec.AbsResourcesDir, path))
 			}
 			return nil
 		})
 
-		return counter, err
+		return removed, err
 	}
 
-	imageCounter, err1 := walker(imageCacheDir, isImageInUse)
-	assetsCounter, err2 := walker(assetsCacheDir, isAssetInUse)
-	totalCount := imageCounter + assetsCounter
+	imageRemoved, err1 := walker(imageCacheDir, isImageInUse)
+	assetsRemoved, err2 := walker(assetsCacheDir, isAssetInUse)
+	publishRemoved, err3 := h.gcPublishDir(dryRun)
+
+	removed := append(append(imageRemoved, assetsRemoved...), publishRemoved...)
+	sort.Strings(removed)
 
 	if err1 != nil {
-		return totalCount, err1
+		return removed, err1
+	}
+
+	if err2 != nil {
+		return removed, err2
+	}
+
+	return removed, err3
+
+}
+
+// gcPublishDir removes the files in the publish dir that were not written by
+// the last build and do not come from any static dir. Files and dirs starting
+// with a dot, e.g. .git, are left alone. It returns the absolute filenames.
+func (h *HugoSites) gcPublishDir(dryRun bool) ([]string, error) {
+	tracker := h.PathSpec.BaseFs.PublishTracker
+	if tracker == nil || !h.Cfg.GetBool("gc") {
+		return nil, nil
 	}
 
-	return totalCount, err2
+	s := h.Sites[0]
+	fs := tracker.Fs
+
+	isStatic := func(filename string) bool {
+		for _, sfs := range h.PathSpec.BaseFs.Static {
+			name := filename
+			if sfs.PublishFolder != "" {
+				prefix := sfs.PublishFolder + helpers.FilePathSeparator
+				if !strings.HasPrefix(name, prefix) {
+					continue
+				}
+				name = strings.TrimPrefix(name, prefix)
+			}
+			if fi, err := sfs.Fs.Stat(name); err == nil && !fi.IsDir() {
+				return true
+			}
+		}
+		return false
+	}
+
+	var (
+		removed []string
+		dirs    []string
+	)
+
+	err := afero.Walk(fs, helpers.FilePathSeparator, func(path string, info os.FileInfo, err error) error {
+		if info == nil {
+			return nil
+		}
 
+		rel := strings.TrimPrefix(path, helpers.FilePathSeparator)
+		if rel == "" {
+			return nil
+		}
+
+		if strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if info.IsDir() {
+			dirs = append(dirs, path)
+			return nil
+		}
+
+		if tracker.IsTouched(rel) || isStatic(rel) {
+			return nil
+		}
+
+		if removeFile(fs, path, dryRun, s.Log) {
+			removed = append(removed, filepath.Join(h.PathSpec.AbsPublishDir, rel))
+		}
+
+		return nil
+	})
+
+	if !dryRun {
+		// Remove any empty dirs left behind, deepest first.
+		for i := len(dirs) - 1; i >= 0; i-- {
+			if empty, _ := afero.IsEmpty(fs, dirs[i]); empty {
+				fs.Remove(dirs[i])
+			}
+		}
+	}
+
+	return removed, err
+}
+
+// removeFile removes filename from fs unless this is a dry run. It reports
+// whether the file was, or in a dry run would have been, removed.
+func removeFile(fs afero.Fs, filename string, dryRun bool, logger *jww.Notepad) bool {
+	if dryRun {
+		return true
+	}
+	if err := fs.Remove(filename); err != nil && !os.IsNotExist(err) {
+		logger.ERROR.Printf("Failed to remove %q: %s", filename, err)
+		return false
+	}
+	return true
 }
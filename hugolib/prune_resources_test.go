@@ -0,0 +1,101 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGCPublishDir(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+gc = true
+`)
+	b.WithContent("p1.md", "---\ntitle: p1\n---\n")
+	b.WithTemplates("index.html", `Home`, "_default/single.html", `Single`)
+	b.WithSourceFile("static/s.txt", "static")
+
+	for _, filename := range []string{"public/s.txt", "public/old/index.html", "public/.git/config"} {
+		assert.NoError(afero.WriteFile(b.Fs.Destination, filepath.FromSlash(filename), []byte("content"), 0755))
+	}
+
+	b.Build(BuildCfg{})
+
+	count, err := b.H.GC()
+	assert.NoError(err)
+	assert.Equal(1, count)
+
+	assert.False(b.CheckExists("public/old/index.html"))
+	assert.False(b.CheckExists("public/old"))
+	assert.True(b.CheckExists("public/index.html"))
+	assert.True(b.CheckExists("public/p1/index.html"))
+	assert.True(b.CheckExists("public/s.txt"))
+	assert.True(b.CheckExists("public/.git/config"))
+}
+
+func TestGCDryRun(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+gc = true
+`)
+	b.WithContent("p1.md", "---\ntitle: p1\n---\n")
+	b.WithTemplates("index.html", `Home`, "_default/single.html", `Single`)
+
+	for _, filename := range []string{"public/old/index.html", "public/b.txt"} {
+		assert.NoError(afero.WriteFile(b.Fs.Destination, filepath.FromSlash(filename), []byte("content"), 0755))
+	}
+
+	b.Build(BuildCfg{})
+
+	staleImage := filepath.Join(b.H.PathSpec.AbsResourcesDir, "_gen", "images", "old.jpg")
+	assert.NoError(afero.WriteFile(b.Fs.Source, staleImage, []byte("content"), 0755))
+
+	files, err := b.H.GCDryRun()
+	assert.NoError(err)
+
+	// The cached and the published files are all listed with their absolute filenames.
+	publishDir := b.H.PathSpec.AbsPublishDir
+	assert.Equal([]string{
+		filepath.Join(publishDir, "b.txt"),
+		filepath.Join(publishDir, "old", "index.html"),
+		staleImage,
+	}, files)
+
+	assert.True(b.CheckExists("public/old/index.html"))
+	assert.True(b.CheckExists("public/b.txt"))
+
+	// Running it again gives the same result.
+	again, err := b.H.GCDryRun()
+	assert.NoError(err)
+	assert.Equal(files, again)
+
+	count, err := b.H.GC()
+	assert.NoError(err)
+	assert.Equal(3, count)
+	assert.False(b.CheckExists("public/old/index.html"))
+}
@@ -208,3 +208,34 @@ $color: #333;
 		test.verify(b)
 	}
 }
+
+func TestResourceGetFromContent(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithSimpleConfigFile()
+
+	b.WithContent("page.md", `
+---
+title: Hello
+---
+
+`)
+	b.WithContent(filepath.Join("snippets", "intro.md"), "Some *intro*.")
+	b.WithContent(filepath.Join("snippets", "shared.txt"), "From content")
+	b.WithSourceFile(filepath.Join("assets", "snippets", "shared.txt"), "From assets")
+
+	b.WithTemplates("home.html", `
+{{ $intro := resources.Get "snippets/intro.md" }}
+Intro: {{ $intro.Content | markdownify }}|
+Shared: {{ (resources.Get "snippets/shared.txt").Content }}|
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html",
+		"Intro: Some <em>intro</em>.|",
+		// Assets wins.
+		"Shared: From assets|",
+	)
+}
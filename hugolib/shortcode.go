@@ -324,7 +324,8 @@ func renderShortcode(
 				Cfg:          p.Language(),
 				DocumentID:   p.UniqueID(),
 				DocumentName: p.Path(),
-				Config:       p.getRenderingConfig()})
+				Config:       p.getRenderingConfig(),
+				RenderHooks:  p.renderHooks()})
 
 			// If the type is “unknown” or “markdown”, we assume the markdown
 			// generation has been performed. Given the input: `a line`, markdown
@@ -21,6 +21,7 @@ import (
 	"mime"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -116,6 +117,15 @@ type Site struct {
 
 	disabledKinds map[string]bool
 
+	// Kinds disabled for a content section and its descendants, keyed by
+	// the section path, e.g. "blog" or "docs/internal".
+	sectionDisabledKinds map[string]map[string]bool
+
+	// The top-level sections set in renderSegments. If set, pages in other
+	// sections are still built, so references to them resolve, but they
+	// are not rendered.
+	renderSegments map[string]bool
+
 	// Output formats defined in site config per Page Kind, or some defaults
 	// if not set.
 	// Output formats defined in Page front matter will override these.
@@ -176,21 +186,53 @@ func (s *Site) isEnabled(kind string) bool {
 	return !s.disabledKinds[kind]
 }
 
+// isEnabledInSection is like isEnabled, but also checks the kinds disabled
+// for the given content section or any of its ancestors in
+// disableKindsInSections:
+//
+//	[disableKindsInSections]
+//	blog = ["RSS", "taxonomy"]
+func (s *Site) isEnabledInSection(kind string, sections []string) bool {
+	return s.isEnabled(kind) && !s.isDisabledInSection(kind, sections)
+}
+
+// isDisabledInSection reports whether kind is disabled for the given content
+// section or any of its ancestors in disableKindsInSections.
+func (s *Site) isDisabledInSection(kind string, sections []string) bool {
+	for i := len(sections); i > 0; i-- {
+		if s.sectionDisabledKinds[strings.ToLower(path.Join(sections[:i]...))][kind] {
+			return true
+		}
+	}
+	return false
+}
+
+// inRenderSegments reports whether p is in one of the sections set in
+// renderSegments, or true if renderSegments is not set.
+func (s *Site) inRenderSegments(p *Page) bool {
+	if len(s.renderSegments) == 0 {
+		return true
+	}
+	return s.renderSegments[p.Section()]
+}
+
 // reset returns a new Site prepared for rebuild.
 func (s *Site) reset() *Site {
 	return &Site{Deps: s.Deps,
-		layoutHandler:       output.NewLayoutHandler(),
-		disabledKinds:       s.disabledKinds,
-		titleFunc:           s.titleFunc,
-		relatedDocsHandler:  newSearchIndexHandler(s.relatedDocsHandler.cfg),
-		outputFormats:       s.outputFormats,
-		rc:                  s.rc,
-		outputFormatsConfig: s.outputFormatsConfig,
-		frontmatterHandler:  s.frontmatterHandler,
-		mediaTypesConfig:    s.mediaTypesConfig,
-		Language:            s.Language,
-		owner:               s.owner,
-		PageCollections:     newPageCollections()}
+		layoutHandler:        output.NewLayoutHandler(),
+		disabledKinds:        s.disabledKinds,
+		sectionDisabledKinds: s.sectionDisabledKinds,
+		renderSegments:       s.renderSegments,
+		titleFunc:            s.titleFunc,
+		relatedDocsHandler:   newSearchIndexHandler(s.relatedDocsHandler.cfg),
+		outputFormats:        s.outputFormats,
+		rc:                   s.rc,
+		outputFormatsConfig:  s.outputFormatsConfig,
+		frontmatterHandler:   s.frontmatterHandler,
+		mediaTypesConfig:     s.mediaTypesConfig,
+		Language:             s.Language,
+		owner:                s.owner,
+		PageCollections:      newPageCollections()}
 }
 
 // newSite creates a new site with the given configuration.
@@ -206,6 +248,24 @@ func newSite(cfg deps.DepsCfg) (*Site, error) {
 		disabledKinds[disabled] = true
 	}
 
+	sectionDisabledKinds := make(map[string]map[string]bool)
+	for section, kinds := range cast.ToStringMap(cfg.Language.Get("disableKindsInSections")) {
+		// The config keys are lower case, so match the sections case-insensitively.
+		section = strings.ToLower(strings.Trim(filepath.ToSlash(section), "/"))
+		sectionDisabledKinds[section] = make(map[string]bool)
+		for _, disabled := range cast.ToStringSlice(kinds) {
+			sectionDisabledKinds[section][disabled] = true
+		}
+	}
+
+	var renderSegments map[string]bool
+	if segments := cast.ToStringSlice(cfg.Language.Get("renderSegments")); len(segments) > 0 {
+		renderSegments = make(map[string]bool)
+		for _, segment := range segments {
+			renderSegments[strings.Trim(filepath.ToSlash(segment), "/")] = true
+		}
+	}
+
 	var (
 		mediaTypesConfig    []map[string]interface{}
 		outputFormatsConfig []map[string]interface{}
@@ -263,17 +323,19 @@ func newSite(cfg deps.DepsCfg) (*Site, error) {
 	}
 
 	s := &Site{
-		PageCollections:     c,
-		layoutHandler:       output.NewLayoutHandler(),
-		Language:            cfg.Language,
-		disabledKinds:       disabledKinds,
-		titleFunc:           titleFunc,
-		relatedDocsHandler:  newSearchIndexHandler(relatedContentConfig),
-		outputFormats:       outputFormats,
-		rc:                  &siteRenderingContext{output.HTMLFormat},
-		outputFormatsConfig: siteOutputFormatsConfig,
-		mediaTypesConfig:    siteMediaTypesConfig,
-		frontmatterHandler:  frontMatterHandler,
+		PageCollections:      c,
+		layoutHandler:        output.NewLayoutHandler(),
+		Language:             cfg.Language,
+		disabledKinds:        disabledKinds,
+		sectionDisabledKinds: sectionDisabledKinds,
+		renderSegments:       renderSegments,
+		titleFunc:            titleFunc,
+		relatedDocsHandler:   newSearchIndexHandler(relatedContentConfig),
+		outputFormats:        outputFormats,
+		rc:                   &siteRenderingContext{output.HTMLFormat},
+		outputFormatsConfig:  siteOutputFormatsConfig,
+		mediaTypesConfig:     siteMediaTypesConfig,
+		frontmatterHandler:   frontMatterHandler,
 	}
 
 	s.Info = newSiteInfo(siteBuilderCfg{s: s, pageCollections: c, language: s.Language})
@@ -468,6 +530,7 @@ func newSiteInfo(cfg siteBuilderCfg) SiteInfo {
 type SiteSocial map[string]string
 
 // Param is a convenience method to do lookups in SiteInfo's Params map.
+// Nested params can be looked up with a dotted key, e.g. "social.twitter".
 //
 // This method is also implemented on Page and Node.
 func (s *SiteInfo) Param(key interface{}) (interface{}, error) {
@@ -476,17 +539,32 @@ func (s *SiteInfo) Param(key interface{}) (interface{}, error) {
 		return nil, err
 	}
 	keyStr = strings.ToLower(keyStr)
-	return s.Params[keyStr], nil
+	if v, found := s.Params[keyStr]; found {
+		return v, nil
+	}
+
+	keySegments := strings.Split(keyStr, ".")
+	if len(keySegments) == 1 {
+		return nil, nil
+	}
+
+	return traverse(keySegments, s.Params), nil
 }
 
 func (s *SiteInfo) IsMultiLingual() bool {
 	return len(s.Languages) > 1
 }
 
+// IsServer returns whether the site is being served by the Hugo server.
 func (s *SiteInfo) IsServer() bool {
 	return s.owner.running
 }
 
+// Environment returns the build environment, e.g. "production" or "development".
+func (s *SiteInfo) Environment() string {
+	return s.owner.environment
+}
+
 func (s *SiteInfo) refLink(ref string, page *Page, relative bool, outputFormat string) (string, error) {
 	var refURL *url.URL
 	var err error
@@ -504,9 +582,10 @@ func (s *SiteInfo) refLink(ref string, page *Page, relative bool, outputFormat s
 	var link string
 
 	if refURL.Path != "" {
-		target := s.getPage(KindPage, refURL.Path)
+		target = s.getPage(KindPage, refURL.Path)
 
 		if target == nil {
+			s.s.Warnings.Incr(helpers.WarningBrokenRef)
 			return "", fmt.Errorf("No page found with path or logical name \"%s\".\n", refURL.Path)
 		}
 
@@ -529,12 +608,21 @@ func (s *SiteInfo) refLink(ref string, page *Page, relative bool, outputFormat s
 	}
 
 	if refURL.Fragment != "" {
-		link = link + "#" + refURL.Fragment
+		anchor := refURL.Fragment
+		anchorPage := page
+
+		if refURL.Path != "" && target != nil {
+			anchorPage = target
+		}
+
+		if anchorPage != nil && !anchorPage.getRenderingConfig().PlainIDAnchors {
+			anchor = anchor + ":" + anchorPage.UniqueID()
+		}
+
+		link = link + "#" + anchor
 
-		if refURL.Path != "" && target != nil && !target.getRenderingConfig().PlainIDAnchors {
-			link = link + ":" + target.UniqueID()
-		} else if page != nil && !page.getRenderingConfig().PlainIDAnchors {
-			link = link + ":" + page.UniqueID()
+		if anchorPage != nil && s.s.owner != nil {
+			s.s.owner.addRefAnchor(ref, anchor, anchorPage)
 		}
 	}
 
@@ -891,14 +979,14 @@ func (s *Site) handleDataFile(r source.ReadableFile) error {
 			higherPrecedentMap := higherPrecedentData.(map[string]interface{})
 			for key, value := range data.(map[string]interface{}) {
 				if _, exists := higherPrecedentMap[key]; exists {
-					s.Log.WARN.Printf("Data for key '%s' in path '%s' is overridden higher precedence data already in the data tree", key, r.Path())
+					s.Warnings.Printf(s.Log.WARN, helpers.WarningDataOverride, "Data for key '%s' in path '%s' is overridden higher precedence data already in the data tree", key, r.Path())
 				} else {
 					higherPrecedentMap[key] = value
 				}
 			}
 		default:
 			// can't merge: higherPrecedentData is not a map
-			s.Log.WARN.Printf("The %T data from '%s' overridden by "+
+			s.Warnings.Printf(s.Log.WARN, helpers.WarningDataOverride, "The %T data from '%s' overridden by "+
 				"higher precedence %T data already in the data tree", data, r.Path(), higherPrecedentData)
 		}
 
@@ -907,7 +995,7 @@ func (s *Site) handleDataFile(r source.ReadableFile) error {
 			current[r.BaseFileName()] = data
 		} else {
 			// we don't merge array data
-			s.Log.WARN.Printf("The %T data from '%s' overridden by "+
+			s.Warnings.Printf(s.Log.WARN, helpers.WarningDataOverride, "The %T data from '%s' overridden by "+
 				"higher precedence %T data already in the data tree", data, r.Path(), higherPrecedentData)
 		}
 
@@ -976,12 +1064,15 @@ func (s *Site) setupSitePages() {
 		if i > 0 {
 			page.Prev = s.RegularPages[i-1]
 		}
+	}
 
-		// Determine Site.Info.LastChange
-		// Note that the logic to determine which date to use for Lastmod
-		// is already applied, so this is *the* date to use.
-		// We cannot just pick the last page in the default sort, because
-		// that may not be ordered by date.
+	// Determine Site.Info.LastChange from all pages, including the home page
+	// and sections with a lastmod set in their _index.md.
+	// Note that the logic to determine which date to use for Lastmod
+	// is already applied, so this is *the* date to use.
+	// We cannot just pick the last page in the default sort, because
+	// that may not be ordered by date.
+	for _, page := range s.Pages {
 		if page.Lastmod.After(siteLastChange) {
 			siteLastChange = page.Lastmod
 		}
@@ -998,6 +1089,10 @@ func (s *Site) render(config *BuildCfg, outFormatIdx int) (err error) {
 		}
 		s.timerStep("prepare pages")
 
+		if err = s.checkDuplicateTargetPaths(); err != nil {
+			return
+		}
+
 		// Note that even if disableAliases is set, the aliases themselves are
 		// preserved on page. The motivation with this is to be able to generate
 		// 301 redirects in a .htacess file and similar using a custom output format.
@@ -1125,9 +1220,14 @@ func (s *Site) initializeSiteInfo() error {
 				return vvv
 			}
 		default:
-			m := cast.ToStringMapBool(v)
+			// Per section, e.g. { legacy = true }. The keys may have been
+			// lower cased by the config loader, so match case-insensitively.
+			m := make(map[string]bool)
+			for section, ugly := range cast.ToStringMapBool(v) {
+				m[strings.ToLower(section)] = ugly
+			}
 			uglyURLs = func(p *Page) bool {
-				return m[p.Section()]
+				return m[strings.ToLower(p.Section())]
 			}
 		}
 	}
@@ -1373,10 +1473,10 @@ func (s *Site) assembleMenus() {
 
 	if sectionPagesMenu != "" {
 		for _, p := range pages {
-			if p.Kind == KindSection {
-				// From Hugo 0.22 we have nested sections, but until we get a
-				// feel of how that would work in this setting, let us keep
-				// this menu for the top level only.
+			// From Hugo 0.22 we have nested sections, but until we get a
+			// feel of how that would work in this setting, let us keep
+			// this menu for the top level only.
+			if p.Kind == KindSection && len(p.sections) == 1 {
 				id := p.Section()
 				if _, ok := flat[twoD{sectionPagesMenu, id}]; ok {
 					continue
@@ -1457,10 +1557,37 @@ func (s *Site) assembleTaxonomies() {
 
 	s.Log.INFO.Printf("found taxonomies: %#v\n", taxonomies)
 
+	// When preserving taxonomy names, terms that only differ in case, e.g.
+	// "Go" and "go", can be merged into one, named after the first seen.
+	caseInsensitive := s.Info.preserveTaxonomyNames && s.Language.GetBool("caseInsensitiveTaxonomyTerms")
+
 	for singular, plural := range taxonomies {
 		s.taxonomiesPluralSingular[plural] = singular
 
+		termNames := make(map[string]string)
+
+		addTerm := func(p *Page, term string, weight int) {
+			if caseInsensitive {
+				lterm := strings.ToLower(term)
+				if name, found := termNames[lterm]; found {
+					term = name
+				} else {
+					termNames[lterm] = term
+				}
+			}
+			x := WeightedPage{p.termWeight(plural, term, weight), p}
+			s.Taxonomies[plural].add(s.getTaxonomyKey(term), x)
+			if s.Info.preserveTaxonomyNames {
+				// Need to track the original
+				s.taxonomiesOrigKey[fmt.Sprintf("%s-%s", plural, s.PathSpec.MakePathSanitized(term))] = term
+			}
+		}
+
 		for _, p := range s.Pages {
+			if s.isDisabledInSection(KindTaxonomy, p.sections) || s.isDisabledInSection(KindTaxonomyTerm, p.sections) {
+				// Pages in this section are kept out of the taxonomies.
+				continue
+			}
 			vals := p.getParam(plural, !s.Info.preserveTaxonomyNames)
 			weight := p.getParamToLower(plural + "_weight")
 			if weight == nil {
@@ -1469,20 +1596,10 @@ func (s *Site) assembleTaxonomies() {
 			if vals != nil {
 				if v, ok := vals.([]string); ok {
 					for _, idx := range v {
-						x := WeightedPage{weight.(int), p}
-						s.Taxonomies[plural].add(s.getTaxonomyKey(idx), x)
-						if s.Info.preserveTaxonomyNames {
-							// Need to track the original
-							s.taxonomiesOrigKey[fmt.Sprintf("%s-%s", plural, s.PathSpec.MakePathSanitized(idx))] = idx
-						}
+						addTerm(p, idx, weight.(int))
 					}
 				} else if v, ok := vals.(string); ok {
-					x := WeightedPage{weight.(int), p}
-					s.Taxonomies[plural].add(s.getTaxonomyKey(v), x)
-					if s.Info.preserveTaxonomyNames {
-						// Need to track the original
-						s.taxonomiesOrigKey[fmt.Sprintf("%s-%s", plural, s.PathSpec.MakePathSanitized(v))] = v
-					}
+					addTerm(p, v, weight.(int))
 				} else {
 					s.Log.ERROR.Printf("Invalid %s in %s\n", plural, p.File.Path())
 				}
@@ -1516,6 +1633,7 @@ func (s *Site) resetBuildState() {
 		p.parent = nil
 		p.scratch = maps.NewScratch()
 		p.mainPageOutput = nil
+		p.resetPaginatorPageOutput()
 	}
 }
 
@@ -1602,12 +1720,18 @@ func (s *Site) appendThemeTemplates(in []string) []string {
 }
 
 // GetPage looks up a page of a given type in the path given.
-//    {{ with .Site.GetPage "section" "blog" }}{{ .Title }}{{ end }}
+//
+//	{{ with .Site.GetPage "section" "blog" }}{{ .Title }}{{ end }}
 //
 // This will return nil when no page could be found, and will return the
-// first page found if the key is ambigous.
+// first page found if the key is ambigous. If strictGetPage is enabled in the
+// site config, a missing page is reported as an error, failing the build.
 func (s *SiteInfo) GetPage(typ string, path ...string) (*Page, error) {
-	return s.getPage(typ, path...), nil
+	p := s.getPage(typ, path...)
+	if p == nil && s.s.Cfg.GetBool("strictGetPage") {
+		return nil, fmt.Errorf("GetPage: no page of kind %q found with path %q", typ, strings.Join(path, "/"))
+	}
+	return p, nil
 }
 
 func (s *Site) permalinkForOutputFormat(link string, f output.Format) (string, error) {
@@ -1662,6 +1786,10 @@ func (s *Site) renderAndWriteXML(statCounter *uint64, name string, dest string,
 		return nil
 	}
 
+	if err := s.owner.visitRendered(dest, outBuffer.Bytes()); err != nil {
+		return err
+	}
+
 	return s.publish(statCounter, dest, outBuffer)
 
 }
@@ -1721,6 +1849,10 @@ func (s *Site) renderAndWritePage(statCounter *uint64, name string, dest string,
 		return nil
 	}
 
+	if err := s.owner.visitRendered(dest, outBuffer.Bytes()); err != nil {
+		return err
+	}
+
 	return s.publish(statCounter, dest, outBuffer)
 }
 
@@ -1748,6 +1880,8 @@ func (s *Site) renderForLayouts(name string, d interface{}, w io.Writer, layouts
 		return fmt.Errorf("[%s] Unable to locate layout for %q: %s\n", s.Language.Lang, name, layouts)
 	}
 
+	s.Log.DEBUG.Printf("[%s] Render %q with template %q", s.Language.Lang, name, templ.Name())
+
 	if err = templ.Execute(w, d); err != nil {
 		// Behavior here should be dependent on if running in server or watch mode.
 		if p, ok := d.(*PageOutput); ok {
@@ -0,0 +1,56 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildConfigAuxiliaryFiles(t *testing.T) {
+	t.Parallel()
+
+	for _, disabled := range []bool{false, true} {
+		b := newTestSitesBuilder(t)
+		b.WithConfigFile("toml", fmt.Sprintf(`
+baseURL = "http://example.com/"
+paginate = 1
+defaultContentLanguage = "en"
+defaultContentLanguageInSubdir = true
+
+[build]
+noPaginatorAliases = %t
+noLanguageRedirect = %t
+
+[languages]
+[languages.en]
+weight = 1
+[languages.nn]
+weight = 2
+`, disabled, disabled))
+
+		b.WithContent("p1.md", "---\ntitle: p1\n---\n", "p2.md", "---\ntitle: p2\n---\n")
+		b.WithTemplates("index.html", `Page: {{ .Paginator.PageNumber }}`, "_default/single.html", `Single`)
+
+		b.Build(BuildCfg{})
+
+		b.AssertFileContent("public/en/index.html", "Page: 1")
+		b.AssertFileContent("public/en/page/2/index.html", "Page: 2")
+
+		require.Equal(t, !disabled, b.CheckExists("public/en/page/1/index.html"), "paginator alias")
+		require.Equal(t, !disabled, b.CheckExists("public/index.html"), "language redirect")
+	}
+}
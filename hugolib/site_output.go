@@ -105,3 +105,14 @@ func createSiteOutputFormats(allFormats output.Formats, cfg config.Provider) (ma
 	return outFormats, nil
 
 }
+
+// withoutOutputFormat returns a copy of formats without the named format.
+func withoutOutputFormat(formats output.Formats, name string) output.Formats {
+	filtered := make(output.Formats, 0, len(formats))
+	for _, f := range formats {
+		if !strings.EqualFold(f.Name, name) {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
@@ -337,6 +337,37 @@ baseName = "customdelimbase"
 
 }
 
+func TestSearchIndexOutputFormat(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+[outputs]
+home = ["HTML", "SearchIndex"]
+`)
+	b.WithContent("blog/p1.md", `---
+title: "Page \"One\""
+tags: ["hugo", "search"]
+---
+Some **searchable** content.
+`, "blog/p2.md", `---
+title: Page Two
+---
+More content.
+`)
+	b.WithTemplatesAdded("index.html", `Index: {{ with .OutputFormats.Get "SearchIndex" }}{{ .RelPermalink }}{{ end }}`)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "Index: /searchindex.json")
+	b.AssertFileContent("public/searchindex.json",
+		`{"title": "Page \"One\"", "content": "Some searchable content.\n", "tags": ["hugo","search"], "url": "http://example.com/blog/p1/"}`,
+		`{"title": "Page Two", "content": "More content.\n", "tags": [], "url": "http://example.com/blog/p2/"}`,
+	)
+
+	require.False(t, b.CheckExists("public/blog/p1/searchindex.json"))
+}
+
 func TestCreateSiteOutputFormats(t *testing.T) {
 	assert := require.New(t)
 
@@ -416,3 +447,28 @@ func TestCreateSiteOutputFormatsCustomFormats(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(output.Formats{customHTML, customRSS}, outputs[KindHome])
 }
+
+func TestLayoutLookupOrder(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+	b.WithContent("blog/post.md", `---
+title: Post
+---
+`, "blog/custom.md", `---
+title: Custom
+layout: mylayout
+---
+`)
+	b.WithTemplates("_default/single.html", `Layouts: {{ delimit .LayoutLookupOrder "|" }}|Render: {{ delimit (.LayoutLookupOrder "li") "|" }}`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/blog/post/index.html",
+		"Layouts: blog/single.en.html.html|blog/single.html.html|blog/single.en.html|blog/single.html|_default/single.en.html.html|_default/single.html.html|_default/single.en.html|_default/single.html|",
+		"Render: blog/li.en.html.html|blog/li.html.html|blog/li.en.html|blog/li.html|_default/li.en.html.html|_default/li.html.html|_default/li.en.html|_default/li.html",
+	)
+	b.AssertFileContent("public/blog/custom/index.html",
+		"Layouts: blog/mylayout.en.html.html|blog/single.en.html.html|blog/mylayout.html.html|blog/single.html.html|",
+	)
+}
@@ -17,9 +17,11 @@ import (
 	"bytes"
 	"fmt"
 	"path"
+	"sort"
 	"strings"
 	"sync"
 
+	"github.com/gohugoio/hugo/helpers"
 	"github.com/gohugoio/hugo/output"
 )
 
@@ -48,7 +50,7 @@ func (s *Site) renderPages(cfg *BuildCfg) error {
 	}
 
 	for _, page := range s.Pages {
-		if cfg.shouldRender(page) {
+		if cfg.shouldRender(page) && s.inRenderSegments(page) {
 			pages <- page
 		}
 	}
@@ -66,6 +68,54 @@ func (s *Site) renderPages(cfg *BuildCfg) error {
 	return nil
 }
 
+// checkDuplicateTargetPaths reports pages that will be written to the same
+// target path, where the last one written silently wins. This is only done
+// if printPathWarnings or strictTargetPaths is enabled; the latter reports
+// the duplicates as an error, failing the build.
+func (s *Site) checkDuplicateTargetPaths() error {
+	strict := s.Cfg.GetBool("strictTargetPaths")
+	if !strict && !s.Cfg.GetBool("printPathWarnings") {
+		return nil
+	}
+
+	sources := make(map[string][]string)
+	for _, p := range s.Pages {
+		if p.headless || !s.inRenderSegments(p) {
+			continue
+		}
+		for _, f := range p.outputFormats {
+			target, err := p.createTargetPath(f, false)
+			if err != nil {
+				return err
+			}
+			sources[target] = append(sources[target], p.pathOrTitle())
+		}
+	}
+
+	var duplicates []string
+	for target, paths := range sources {
+		if len(paths) > 1 {
+			duplicates = append(duplicates, fmt.Sprintf("%q from %s", target, strings.Join(paths, ", ")))
+		}
+	}
+
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	sort.Strings(duplicates)
+
+	if strict {
+		return fmt.Errorf("duplicate target paths: %s", strings.Join(duplicates, "; "))
+	}
+
+	for _, d := range duplicates {
+		s.Warnings.Printf(s.Log.WARN, helpers.WarningDuplicatePath, "Duplicate target path %s", d)
+	}
+
+	return nil
+}
+
 func headlessPagesPublisher(s *Site, wg *sync.WaitGroup) {
 	defer wg.Done()
 	for _, page := range s.headlessPages {
@@ -169,8 +219,9 @@ func pageRenderer(s *Site, pages <-chan *Page, results chan<- error, wg *sync.Wa
 					results <- err
 				}
 
-				// Only render paginators for the main output format
-				if i == 0 && pageOutput.IsNode() {
+				// Only render paginators for the main output format and the
+				// formats that are paginated, see output.Format.Paginate.
+				if pageOutput.IsNode() && (i == 0 || outFormat.IsHTML || outFormat.Paginate) {
 					if err := s.renderPaginator(pageOutput); err != nil {
 						results <- err
 					}
@@ -185,19 +236,21 @@ func pageRenderer(s *Site, pages <-chan *Page, results chan<- error, wg *sync.Wa
 func (s *Site) renderPaginator(p *PageOutput) error {
 	if p.paginator != nil {
 		s.Log.DEBUG.Printf("Render paginator for page %q", p.Path())
-		paginatePath := s.Cfg.GetString("paginatePath")
+		paginatePath := paginatePathForFormat(s.Cfg.GetString("paginatePath"), p.outputFormat)
 
 		// write alias for page 1
-		addend := fmt.Sprintf("/%s/%d", paginatePath, 1)
-		target, err := p.createTargetPath(p.outputFormat, false, addend)
-		if err != nil {
-			return err
-		}
+		if p.outputFormat.IsHTML && !s.Info.Config.Build.NoPaginatorAliases {
+			addend := fmt.Sprintf("/%s/%d", paginatePath, 1)
+			target, err := p.createTargetPath(p.outputFormat, false, addend)
+			if err != nil {
+				return err
+			}
 
-		// TODO(bep) do better
-		link := newOutputFormat(p.Page, p.outputFormat).Permalink()
-		if err := s.writeDestAlias(target, link, nil); err != nil {
-			return err
+			// TODO(bep) do better
+			link := newOutputFormat(p.Page, p.outputFormat).Permalink()
+			if err := s.writeDestAlias(target, link, nil); err != nil {
+				return err
+			}
 		}
 
 		pagers := p.paginator.Pagers()
@@ -316,12 +369,15 @@ func (s *Site) renderSitemap() error {
 	n := s.newNodePage(kindSitemap)
 
 	// Include all pages (regular, home page, taxonomies etc.)
-	// but filter the empty taxonomies
+	// but filter the empty taxonomies and the sections with the sitemap disabled.
 	var pages Pages
 	for _, p := range s.Pages {
 		if p.Kind == KindTaxonomyTerm && len(p.Pages) == 0 {
 			continue
 		}
+		if s.isDisabledInSection(kindSitemap, p.sections) {
+			continue
+		}
 		pages = append(pages, p)
 	}
 
@@ -419,7 +475,7 @@ func (s *Site) renderRawAliases() error {
 // renderAliases renders shell pages that simply have a redirect in the header.
 func (s *Site) renderAliases() error {
 	for _, p := range s.Pages {
-		if len(p.Aliases) == 0 {
+		if len(p.Aliases) == 0 || !s.inRenderSegments(p) {
 			continue
 		}
 
@@ -451,7 +507,7 @@ func (s *Site) renderAliases() error {
 		}
 	}
 
-	if s.owner.multilingual.enabled() && !s.owner.IsMultihost() {
+	if s.owner.multilingual.enabled() && !s.owner.IsMultihost() && !s.Info.Config.Build.NoLanguageRedirect {
 		mainLang := s.owner.multilingual.DefaultLang
 		if s.Info.defaultContentLanguageInSubdir {
 			mainLangURL := s.PathSpec.AbsURL(mainLang.Lang, false)
@@ -16,6 +16,7 @@ package hugolib
 import (
 	"fmt"
 	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -58,6 +59,17 @@ func (p *Page) CurrentSection() *Page {
 	return v.parent
 }
 
+// FirstSection returns the top level section this page belongs to, or the
+// home page if this is the home page.
+// Note that this will return nil for pages that is not regular, home or section pages.
+func (p *Page) FirstSection() *Page {
+	v := p.CurrentSection()
+	for v != nil && !v.IsHome() && v.parent != nil && !v.parent.IsHome() {
+		v = v.parent
+	}
+	return v
+}
+
 // InSection returns whether the given page is in the current section.
 // Note that this will always return false for pages that are
 // not either regular, home or section pages.
@@ -111,19 +123,40 @@ func (p *Page) IsAncestor(other interface{}) (bool, error) {
 
 // Eq returns whether the current page equals the given page.
 // Note that this is more accurate than doing `{{ if eq $page $otherPage }}`
-// since a Page can be embedded in another type.
+// since a Page can be embedded in another type. Two pages are equal if they
+// have the same kind, language and logical path, e.g. the content file path.
 func (p *Page) Eq(other interface{}) bool {
 	pp, err := unwrapPage(other)
-	if err != nil {
+	if err != nil || pp == nil {
 		return false
 	}
 
-	return p == pp
+	if p == pp {
+		return true
+	}
+
+	return p.Kind == pp.Kind && p.Lang() == pp.Lang() && p.logicalPath() == pp.logicalPath()
+}
+
+// logicalPath returns the path to the page's content file, or, for list pages
+// without one, its sections.
+func (p *Page) logicalPath() string {
+	if p.Source.File != nil && p.Path() != "" {
+		return filepath.ToSlash(p.Path())
+	}
+	return path.Join(p.sections...)
 }
 
 func unwrapPage(in interface{}) (*Page, error) {
-	if po, ok := in.(*PageOutput); ok {
-		in = po.Page
+	switch v := in.(type) {
+	case *PageOutput:
+		in = v.Page
+	case *PageWithoutContent:
+		in = v.Page
+	case *ShortcodeWithPage:
+		if v.Page != nil {
+			in = v.Page.Page
+		}
 	}
 
 	pp, ok := in.(*Page)
@@ -139,6 +172,31 @@ func (p *Page) Sections() Pages {
 	return p.subSections
 }
 
+// RegularPagesRecursive returns all regular pages below this section or home
+// page, at any depth.
+// Note that for non-sections, this method will always return an empty list.
+func (p *Page) RegularPagesRecursive() Pages {
+	if !p.IsHome() && !p.IsSection() {
+		return nil
+	}
+
+	sect := p
+	if sect.origOnCopy != nil {
+		sect = sect.origOnCopy
+	}
+
+	var pages Pages
+	for _, rp := range p.s.RegularPages {
+		for v := rp.parent; v != nil; v = v.parent {
+			if v == sect {
+				pages = append(pages, rp)
+				break
+			}
+		}
+	}
+	return pages
+}
+
 func (s *Site) assembleSections() Pages {
 	var newPages Pages
 
@@ -179,6 +237,11 @@ func (s *Site) assembleSections() Pages {
 			continue
 		}
 
+		if s.isDisabledInSection(KindSection, p.sections[:1]) {
+			// The root section and all below it are disabled.
+			continue
+		}
+
 		sectionKey := path.Join(p.sections...)
 		sect, found := sectionPages[sectionKey]
 
@@ -217,7 +280,7 @@ func (s *Site) assembleSections() Pages {
 			sectionPath := sect.sections[:i]
 			sectionKey := path.Join(sectionPath...)
 			sect, found := sectionPages[sectionKey]
-			if !found {
+			if !found && !s.isDisabledInSection(KindSection, sectionPath) {
 				sect = s.newSectionPage(sectionPath[len(sectionPath)-1])
 				sect.sections = sectionPath
 				sectionPages[sectionKey] = sect
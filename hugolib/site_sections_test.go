@@ -250,6 +250,28 @@ PAG|{{ .Title }}|{{ $sect.InSection . }}
 			assert.NoError(err)
 			assert.False(isAncestor)
 
+			assert.Equal(l1, p.FirstSection())
+			assert.Equal(l1, l1.FirstSection())
+			for _, child := range p.Pages {
+				assert.Equal(p, child.CurrentSection())
+				assert.Equal(l1, child.FirstSection())
+			}
+
+			home := p.s.getPage(KindHome)
+			assert.Equal(home, home.FirstSection())
+
+			assert.Len(p.RegularPagesRecursive(), 2)
+			assert.Len(p.Pages[0].RegularPagesRecursive(), 0)
+			l2 := p.Parent()
+			assert.Len(l2.RegularPagesRecursive(), 5)
+			l1Pages := l1.RegularPagesRecursive()
+			assert.Len(l1Pages, 9)
+			for _, rp := range l1Pages {
+				assert.Equal(KindPage, rp.Kind)
+				assert.Equal("l1", rp.Section())
+			}
+			assert.Len(home.RegularPagesRecursive(), 21)
+
 		}},
 		{"perm a,link", func(p *Page) {
 			assert.Equal("T9_-1", p.title)
@@ -301,3 +323,119 @@ PAG|{{ .Title }}|{{ $sect.InSection . }}
 	th.assertFileContent("public/l1/l2/page/2/index.html", "L1/l2-IsActive: true", "PAG|T2_3|true")
 
 }
+
+func TestSiteHome(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+	b.WithTemplatesAdded("index.html", `Home: {{ .Site.Home.Kind }}|Same: {{ eq .Site.Home (.Site.GetPage "home") }}`)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "Home: home|Same: true")
+
+	s := b.H.Sites[0]
+	home, err := s.Info.Home()
+	assert.NoError(err)
+	assert.NotNil(home)
+	assert.True(home.IsHome())
+	assert.Equal(s.getPage(KindHome), home)
+}
+
+func TestNextPrevInSectionWeighted(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	// The dates are in the opposite order of the weights.
+	for i, date := range []string{"2018-03-01", "2018-02-01", "2018-01-01"} {
+		b.WithContent(fmt.Sprintf("docs/p%d.md", i+1), fmt.Sprintf(`---
+title: P%d
+weight: %d
+date: %s
+---
+`, i+1, 3-i, date))
+	}
+
+	b.WithContent("blog/b1.md", "---\ntitle: B1\ndate: 2018-01-01\n---\n")
+	b.WithContent("blog/b2.md", "---\ntitle: B2\ndate: 2018-02-01\n---\n")
+
+	b.WithTemplates(
+		"_default/single.html", `Prev: {{ with .PrevInSection }}{{ .Title }}{{ end }}|Next: {{ with .NextInSection }}{{ .Title }}{{ end }}|`,
+		"_default/list.html", `Pages: {{ range .Pages }}{{ .Title }}|{{ end }}`,
+	)
+
+	b.Build(BuildCfg{})
+
+	// Next points towards the start of the section's Pages, Prev towards the end.
+	b.AssertFileContent("public/docs/index.html", "Pages: P3|P2|P1|")
+	b.AssertFileContent("public/docs/p3/index.html", "Prev: P2|Next: |")
+	b.AssertFileContent("public/docs/p2/index.html", "Prev: P1|Next: P3|")
+	b.AssertFileContent("public/docs/p1/index.html", "Prev: |Next: P2|")
+
+	// Without weights, the section is ordered by date, newest first.
+	b.AssertFileContent("public/blog/index.html", "Pages: B2|B1|")
+	b.AssertFileContent("public/blog/b2/index.html", "Prev: B1|Next: |")
+	b.AssertFileContent("public/blog/b1/index.html", "Prev: |Next: B2|")
+}
+
+func TestPageEq(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+	b.WithContent("blog/p1.md", "---\ntitle: P1\n---\n{{< eq >}}")
+	b.WithContent("blog/p2.md", "---\ntitle: P2\n---\n{{< eq >}}")
+	b.WithTemplates(
+		"_default/single.html", `{{ $p1 := .Site.GetPage "page" "blog/p1.md" }}Content: {{ .Content }}|Eq p1: {{ .Eq $p1 }}|Eq Page: {{ .Eq .Page }}|Eq section: {{ .Eq .Parent }}|`,
+		"shortcodes/eq.html", `{{ $p1 := .Page.Site.GetPage "page" "blog/p1.md" }}Shortcode eq p1: {{ $p1.Eq .Page }}|Shortcode eq self: {{ .Page.Eq . }}`,
+	)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/blog/p1/index.html", "Shortcode eq p1: true|Shortcode eq self: true", "Eq p1: true|Eq Page: true|Eq section: false|")
+	b.AssertFileContent("public/blog/p2/index.html", "Shortcode eq p1: false|Shortcode eq self: true", "Eq p1: false|Eq Page: true|Eq section: false|")
+
+	s := b.H.Sites[0]
+	p1 := s.getPage(KindPage, "blog/p1.md")
+	p2 := s.getPage(KindPage, "blog/p2.md")
+	assert.NotNil(p1)
+	assert.NotNil(p2)
+
+	// A copy is a different pointer, but the same logical page.
+	p1Copy := *p1
+	assert.True(p1.Eq(&p1Copy))
+	assert.True(p1.Eq(p1.withoutContent()))
+	assert.False(p1.Eq(p2))
+	assert.False(p1.Eq(p2.withoutContent()))
+	assert.False(p1.Eq(nil))
+	assert.False(p1.Eq((*Page)(nil)))
+
+	sect := s.getPage(KindSection, "blog")
+	sectCopy := *sect
+	assert.True(sect.Eq(&sectCopy))
+	assert.False(sect.Eq(p1))
+}
+
+func TestSiteSectionsTopLevelOnly(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithSimpleConfigFile()
+	b.WithContent(
+		"blog/_index.md", "---\ntitle: Blog\nweight: 2\n---\n",
+		"blog/p1.md", "---\ntitle: Blog P1\n---\n",
+		"blog/sub/_index.md", "---\ntitle: Blog Sub\n---\n",
+		"blog/sub/p2.md", "---\ntitle: Blog Sub P2\n---\n",
+		"docs/_index.md", "---\ntitle: Docs\nweight: 1\n---\n",
+		"docs/intro/deep/p3.md", "---\ntitle: Docs Deep P3\n---\n",
+		"about.md", "---\ntitle: About\n---\n",
+	)
+
+	b.WithTemplatesAdded("index.html", `Sections: {{ range .Site.Sections }}{{ .Title }}:{{ .RelPermalink }}|{{ end }}`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "Sections: Docs:/docs/|Blog:/blog/|")
+}
@@ -168,6 +168,37 @@ func TestLastChange(t *testing.T) {
 	require.Equal(t, 2017, s.Info.LastChange.Year(), "Site.LastChange should be set to the page with latest Lastmod (year 2017)")
 }
 
+func TestLastChangeFromLastmod(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+	b.WithContent(
+		"_index.md", "---\ntitle: Home\nlastmod: 2018-03-01\n---\n",
+		"sect/p1.md", "---\ntitle: p1\ndate: 2018-01-01\nlastmod: 2018-02-01\n---\n",
+		"sect/p2.md", "---\ntitle: p2\ndate: 2018-04-01\nlastmod: 2018-05-01\n---\n",
+		"sect/p3.md", "---\ntitle: p3\ndate: 2018-06-01\n---\n",
+	)
+	b.WithTemplatesAdded("index.html", `LastChange: {{ .Site.LastChange.Format "2006-01-02" }}`)
+	b.Build(BuildCfg{})
+
+	s := b.H.Sites[0]
+	p3 := s.getPage(KindPage, "sect/p3.md")
+	require.NotNil(t, p3)
+	require.Equal(t, p3.Lastmod, s.Info.LastChange)
+	b.AssertFileContent("public/index.html", "LastChange: 2018-06-01")
+
+	// A lastmod set on a list page counts, too.
+	b = newTestSitesBuilder(t).WithSimpleConfigFile()
+	b.WithContent(
+		"_index.md", "---\ntitle: Home\nlastmod: 2019-01-01\n---\n",
+		"sect/p1.md", "---\ntitle: p1\nlastmod: 2018-02-01\n---\n",
+	)
+	b.Build(BuildCfg{SkipRender: true})
+
+	require.Equal(t, b.H.Sites[0].getPage(KindHome).Lastmod, b.H.Sites[0].Info.LastChange)
+	require.Equal(t, 2019, b.H.Sites[0].Info.LastChange.Year())
+}
+
 // Issue #_index
 func TestPageWithUnderScoreIndexInFilename(t *testing.T) {
 	t.Parallel()
@@ -930,3 +961,186 @@ func TestRefLinking(t *testing.T) {
 
 	// TODO: and then the failure cases.
 }
+
+func TestRefLinkingOutputFormat(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+	b.WithContent("blog/p1.md", `---
+title: P1
+outputs: ["html", "json"]
+---
+`)
+	b.WithTemplatesAdded("_default/single.json", `JSON`)
+	b.WithTemplatesAdded("index.html", `
+RelRef: {{ relref . "p1.md" }}
+RelRef JSON: {{ relref . (dict "path" "p1.md" "outputFormat" "json") }}
+Ref JSON: {{ ref . (dict "path" "p1.md" "outputFormat" "json") }}
+Ref JSON Args: {{ ref . "p1.md" "json" }}
+`)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html",
+		"RelRef: /blog/p1/\n",
+		"RelRef JSON: /blog/p1/index.json",
+		"Ref JSON: http://example.com/blog/p1/index.json",
+		"Ref JSON Args: http://example.com/blog/p1/index.json",
+	)
+}
+
+func TestRefLinkingAnchors(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+	b.WithContent("blog/p1.md", `---
+title: P1
+---
+
+## Section One
+
+Some text.
+`)
+	b.WithTemplatesAdded("index.html", `
+Valid: {{ relref . "p1.md#section-one" }}
+Unknown: {{ relref . "p1.md#section-nope" }}
+`)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html",
+		"Valid: /blog/p1/#section-one",
+		"Unknown: /blog/p1/#section-nope",
+	)
+
+	assert.Equal(1, b.H.Warnings.Count(helpers.WarningUnknownAnchor))
+}
+
+func TestRefLinkingAnchorsSkipRender(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+	b.WithContent("blog/p1.md", `---
+title: P1
+---
+
+## Section One
+`, "blog/p2.md", `---
+title: P2
+---
+
+[Nope]({{< relref "p1.md#section-nope" >}})
+`)
+	b.CreateSites()
+
+	// The anchors are validated when rendering, so they must not pile up in
+	// the builds that skip it.
+	for i := 0; i < 3; i++ {
+		assert.NoError(b.H.Build(BuildCfg{SkipRender: true}))
+		p2 := b.H.Sites[0].getPage(KindPage, "blog/p2.md")
+		assert.Contains(string(p2.content()), "/blog/p1/#section-nope")
+		assert.Len(b.H.refAnchors, 1)
+	}
+
+	assert.NoError(b.H.Build(BuildCfg{}))
+	assert.Len(b.H.refAnchors, 0)
+	assert.Equal(1, b.H.Warnings.Count(helpers.WarningUnknownAnchor))
+}
+
+func TestSiteIsServerAndEnvironment(t *testing.T) {
+	t.Parallel()
+
+	for _, this := range []struct {
+		running     bool
+		environment string
+		expect      string
+	}{
+		{false, "", "IsServer: false|Environment: production"},
+		{true, "", "IsServer: true|Environment: development"},
+		{true, "staging", "IsServer: true|Environment: staging"},
+	} {
+		b := newTestSitesBuilder(t)
+		config := `baseURL = "http://example.com/"`
+		if this.environment != "" {
+			config += "\nenvironment = \"" + this.environment + "\""
+		}
+		b.WithConfigFile("toml", config)
+		b.WithTemplatesAdded("index.html", `IsServer: {{ .Site.IsServer }}|Environment: {{ .Site.Environment }}`)
+		if this.running {
+			b.Running()
+		}
+		b.Build(BuildCfg{})
+
+		b.AssertFileContent("public/index.html", this.expect)
+	}
+}
+
+func TestRenderSegments(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "http://example.com/"
+renderSegments = ["blog", "docs"]
+`)
+
+	b.WithContent(
+		"blog/b1.md", "---\ntitle: B1\naliases: [/old-b1/]\n---\nSee [N1]({{< ref \"/news/n1.md\" >}}).",
+		"docs/d1.md", "---\ntitle: D1\n---",
+		"news/n1.md", "---\ntitle: N1\naliases: [/old-n1/]\n---",
+	)
+
+	b.WithTemplatesAdded("_default/single.html", `Single: {{ .Title }}|{{ .Content }}`)
+
+	b.Build(BuildCfg{})
+
+	// References to pages in other sections still resolve.
+	b.AssertFileContent("public/blog/b1/index.html", "Single: B1", `href="http://example.com/news/n1/"`)
+	b.AssertFileContent("public/docs/d1/index.html", "Single: D1")
+	b.AssertFileContent("public/blog/index.html", "List Page 1: Blogs")
+	require.True(t, b.CheckExists("public/old-b1/index.html"))
+
+	require.False(t, b.CheckExists("public/news/n1/index.html"))
+	require.False(t, b.CheckExists("public/news/index.html"))
+	require.False(t, b.CheckExists("public/old-n1/index.html"))
+	require.False(t, b.CheckExists("public/index.html"))
+}
+
+func TestDuplicateTargetPaths(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	content := []string{
+		"p1.md", "---\ntitle: P1\nurl: /same/\n---",
+		"blog/p2.md", "---\ntitle: P2\nurl: /same/\n---",
+		"p3.md", "---\ntitle: P3\n---",
+	}
+
+	for _, strict := range []bool{false, true} {
+		b := newTestSitesBuilder(t).WithConfigFile("toml", fmt.Sprintf(`
+baseURL = "http://example.com/"
+printPathWarnings = true
+strictTargetPaths = %t
+`, strict))
+		b.WithContent(content...)
+
+		if strict {
+			b.CreateSites()
+			err := b.H.Build(BuildCfg{})
+			assert.Error(err)
+			assert.Contains(err.Error(), `"/same/index.html" from p1.md, blog/p2.md`)
+			continue
+		}
+
+		b.Build(BuildCfg{})
+		assert.Equal(1, b.H.Warnings.Count(helpers.WarningDuplicatePath))
+	}
+
+	// No warnings unless enabled.
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+	b.WithContent(content...)
+	b.Build(BuildCfg{})
+	assert.Equal(0, b.H.Warnings.Count(helpers.WarningDuplicatePath))
+}
@@ -182,3 +182,29 @@ Do not go gentle into that good night.
 	th.assertFileContent(filepath.Join("public", "ss1", "page", "2", "index.html"), "P2|URL: /ss1/page/2/|Next: /ss1/page/3/")
 
 }
+
+func TestUglyURLsPerSectionFromConfig(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "http://example.com/"
+
+[uglyURLs]
+Legacy = true
+blog = false
+`)
+
+	b.WithContent(
+		"Legacy/p1.md", "---\ntitle: P1\n---\n",
+		"blog/p2.md", "---\ntitle: P2\n---\n",
+		"p3.md", "---\ntitle: P3\n---\n",
+	)
+
+	b.WithTemplatesAdded("_default/single.html", `Single: {{ .Title }}|{{ .RelPermalink }}`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/legacy/p1.html", "Single: P1|/legacy/p1.html")
+	b.AssertFileContent("public/blog/p2/index.html", "Single: P2|/blog/p2/")
+	b.AssertFileContent("public/p3/index.html", "Single: P3|/p3/")
+}
@@ -16,6 +16,7 @@ package hugolib
 import (
 	"fmt"
 	"sort"
+	"strings"
 )
 
 // The TaxonomyList is a list of all taxonomies and their values
@@ -26,6 +27,12 @@ func (tl TaxonomyList) String() string {
 	return fmt.Sprintf("TaxonomyList(%d)", len(tl))
 }
 
+// Get returns the taxonomy with the given plural name, e.g. "tags".
+// The lookup is case insensitive.
+func (tl TaxonomyList) Get(plural string) Taxonomy {
+	return tl[strings.ToLower(plural)]
+}
+
 // A Taxonomy is a map of keywords to a list of pages.
 // For example
 //    TagTaxonomy['technology'] = WeightedPages
@@ -211,3 +211,195 @@ permalinkeds:
 	th.assertFileContent(pathFunc("public/empties/index.html"), "Terms List", "Empties")
 
 }
+
+func TestTaxonomiesTermWeights(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithSimpleConfigFile()
+	b.WithContent("p1.md", `---
+title: P1
+tags:
+  - name: Go
+    weight: 1
+  - name: web
+    weight: 30
+---
+`, "p2.md", `---
+title: P2
+tags: [{name: go, weight: 20}, {name: web, weight: 10}]
+---
+`, "p3.md", `---
+title: P3
+tags: [go, web]
+tags_weight: 15
+---
+`, "p4.md", `+++
+title = "P4"
+tags = [{name = "go", weight = 5}, {name = "web"}]
++++
+`)
+
+	b.WithTemplatesAdded("index.html", `
+Go: {{ range .Site.Taxonomies.tags.go }}{{ .Page.Title }}:{{ .Weight }}|{{ end }}
+Web: {{ range .Site.Taxonomies.tags.web }}{{ .Page.Title }}:{{ .Weight }}|{{ end }}
+Params: {{ range .Site.RegularPages }}{{ .Title }}:{{ .Params.tags }}|{{ end }}
+`, "_default/taxonomy.html", `{{ range .Pages }}{{ .Title }}|{{ end }}`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html",
+		"Go: P1:1|P4:5|P3:15|P2:20|",
+		"Web: P4:0|P2:10|P3:15|P1:30|",
+		"Params: P1:[Go web]|P2:[go web]|P3:[go web]|P4:[go web]|",
+	)
+
+	b.AssertFileContent("public/tags/go/index.html", "P1|P4|P3|P2|")
+	b.AssertFileContent("public/tags/web/index.html", "P4|P2|P3|P1|")
+}
+
+func TestTaxonomiesCountHelpers(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithSimpleConfigFile()
+	b.WithContent(
+		"p1.md", "---\ntitle: P1\ntags: [a, b, c]\n---\n",
+		"p2.md", "---\ntitle: P2\ntags: [b, c]\n---\n",
+		"p3.md", "---\ntitle: P3\ntags: [c, d]\n---\n",
+		"p4.md", "---\ntitle: P4\ntags: [b]\n---\n",
+	)
+
+	b.WithTemplatesAdded("index.html", `
+{{ $tags := .Site.Taxonomies.Get "Tags" }}
+ByCount: {{ range $tags.ByCount }}{{ .Term }}:{{ .Count }}|{{ end }}
+Count: {{ $tags.Count "c" }}|{{ ($tags.Get "b").Count }}|{{ $tags.Count "missing" }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html",
+		"ByCount: b:3|c:3|a:1|d:1|",
+		"Count: 3|3|0",
+	)
+}
+
+func TestTaxonomiesFromNestedParam(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+
+[taxonomies]
+tag = "tags"
+category = "categories"
+
+[taxonomyParams]
+categories = "meta.categories"
+`)
+	b.WithContent("p1.md", `---
+title: P1
+meta:
+  categories: [News, Go]
+---
+`, "p2.md", `+++
+title = "P2"
+[meta]
+categories = ["go"]
++++
+`, "p3.md", `---
+title: P3
+meta:
+  categories: news
+---
+`, "p4.md", `---
+title: P4
+categories: [top-level]
+meta:
+  categories: [ignored]
+---
+`)
+
+	b.WithTemplatesAdded("index.html", `
+{{ range $term, $pages := .Site.Taxonomies.categories }}{{ $term }}:{{ range $pages }}{{ .Title }},{{ end }}|{{ end }}
+P1: {{ range where .Site.RegularPages "Title" "P1" }}{{ .Params.categories }}{{ end }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html",
+		"go:P1,P2,|news:P1,P3,|top-level:P4,|",
+		"P1: [News Go]",
+	)
+	require.True(t, b.CheckExists("public/categories/go/index.html"))
+}
+
+func TestTaxonomyTermPageFromContentFile(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithSimpleConfigFile()
+	b.WithContent("p1.md", `---
+title: P1
+tags: [Go, web]
+---
+`, "tags/go/_index.md", `---
+title: The Go Tag
+description: All about Go.
+color: blue
+---
+Go content.
+`, "tags/_index.md", `---
+title: All the Tags
+description: Tags description.
+---
+`)
+
+	b.WithTemplatesAdded(
+		"_default/taxonomy.html", `Title: {{ .Title }}|Description: {{ .Description }}|Color: {{ .Params.color }}|Content: {{ .Content }}|Pages: {{ range .Pages }}{{ .Title }}{{ end }}`,
+		"_default/terms.html", `Title: {{ .Title }}|Description: {{ .Description }}|Terms: {{ range .Data.Terms.Alphabetical }}{{ .Term }},{{ end }}`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/tags/go/index.html",
+		"Title: The Go Tag|Description: All about Go.|Color: blue|Content: <p>Go content.</p>\n|Pages: P1")
+	b.AssertFileContent("public/tags/web/index.html",
+		"Title: Web|Description: |Color: |Content: |Pages: P1")
+	b.AssertFileContent("public/tags/index.html",
+		"Title: All the Tags|Description: Tags description.|Terms: go,web,")
+}
+
+func TestTaxonomiesCaseInsensitiveTerms(t *testing.T) {
+	t.Parallel()
+
+	for _, caseInsensitive := range []bool{false, true} {
+		b := newTestSitesBuilder(t)
+		b.WithConfigFile("toml", fmt.Sprintf(`
+baseURL = "http://example.com/"
+preserveTaxonomyNames = true
+caseInsensitiveTaxonomyTerms = %t
+`, caseInsensitive))
+		b.WithContent(
+			"p1.md", "---\ntitle: P1\nweight: 1\ntags: [Go, Web]\n---\n",
+			"p2.md", "---\ntitle: P2\nweight: 2\ntags: [go]\n---\n",
+			"p3.md", "---\ntitle: P3\nweight: 3\ntags: [GO, web]\n---\n",
+		)
+
+		b.WithTemplatesAdded(
+			"index.html", `Terms: {{ range .Site.Taxonomies.tags.Alphabetical }}{{ .Term }}:{{ .Count }}|{{ end }}`,
+			"_default/taxonomy.html", `Term: {{ .Data.Term }}|Pages: {{ range .Pages }}{{ .Title }},{{ end }}`,
+		)
+
+		b.Build(BuildCfg{})
+
+		if caseInsensitive {
+			b.AssertFileContent("public/index.html", "Terms: Go:3|Web:2|")
+			b.AssertFileContent("public/tags/go/index.html", "Term: Go|Pages: P1,P2,P3,")
+			b.AssertFileContent("public/tags/web/index.html", "Term: Web|Pages: P1,P3,")
+		} else {
+			b.AssertFileContent("public/index.html", "Terms: GO:1|Go:1|Web:1|go:1|web:1|")
+		}
+	}
+}
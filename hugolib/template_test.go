@@ -14,14 +14,19 @@
 package hugolib
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/gohugoio/hugo/deps"
 	"github.com/gohugoio/hugo/hugofs"
 
+	jww "github.com/spf13/jwalterweatherman"
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTemplateLookupOrder(t *testing.T) {
@@ -212,3 +217,40 @@ Some content
 
 	}
 }
+
+func TestPrintUnusedTemplates(t *testing.T) {
+	t.Parallel()
+
+	var logBuf bytes.Buffer
+	logger := jww.NewNotepad(jww.LevelError, jww.LevelError, &logBuf, ioutil.Discard, "", 0)
+
+	b := newTestSitesBuilder(t).WithLogger(logger).WithConfigFile("toml", `
+baseURL = "http://example.com/"
+printUnusedTemplates = true
+`)
+
+	b.WithContent("p1.md", "---\ntitle: P1\n---\n{{< used >}}")
+
+	b.WithTemplates(
+		"index.html", `Home|{{ partial "used.html" . }}`,
+		"_default/single.html", `{{ define "inner" }}{{ template "partials/nested.html" . }}{{ end }}Single|{{ .Content }}|{{ template "inner" . }}|{{ template "partials/footer.html" . }}`,
+		"_default/list.html", `List`,
+		"partials/used.html", `Used`,
+		"partials/nested.html", `Nested|{{ template "partials/nested-deeper.html" . }}`,
+		"partials/nested-deeper.html", `Deeper`,
+		"partials/footer.html", `Footer`,
+		"partials/unused.html", `Unused|{{ template "partials/only-from-unused.html" . }}`,
+		"partials/only-from-unused.html", `Only from unused`,
+		"shortcodes/used.html", `Used shortcode`,
+		"shortcodes/unused.html", `Unused shortcode`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "Home|Used")
+	b.AssertFileContent("public/p1/index.html", "Single|", "Nested|Deeper|Footer")
+
+	unused := []string{"partials/only-from-unused.html", "partials/unused.html", "shortcodes/unused.html"}
+	require.Equal(t, unused, b.H.TemplateUsage.Unused())
+	require.Contains(t, logBuf.String(), "Unused Templates:\n\n"+strings.Join(unused, "\n")+"\n")
+}
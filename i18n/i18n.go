@@ -29,11 +29,13 @@ type Translator struct {
 	translateFuncs map[string]bundle.TranslateFunc
 	cfg            config.Provider
 	logger         *jww.Notepad
+	warnings       *helpers.WarningCounter
 }
 
 // NewTranslator creates a new Translator for the given language bundle and configuration.
-func NewTranslator(b *bundle.Bundle, cfg config.Provider, logger *jww.Notepad) Translator {
-	t := Translator{cfg: cfg, logger: logger, translateFuncs: make(map[string]bundle.TranslateFunc)}
+// Missing translations are tallied in the given WarningCounter, which may be nil.
+func NewTranslator(b *bundle.Bundle, cfg config.Provider, logger *jww.Notepad, warnings *helpers.WarningCounter) Translator {
+	t := Translator{cfg: cfg, logger: logger, warnings: warnings, translateFuncs: make(map[string]bundle.TranslateFunc)}
 	t.initFuncs(b)
 	return t
 }
@@ -64,6 +66,7 @@ func (t Translator) initFuncs(bndl *bundle.Bundle) {
 	}
 
 	enableMissingTranslationPlaceholders := t.cfg.GetBool("enableMissingTranslationPlaceholders")
+	logI18nWarnings := t.cfg.GetBool("logI18nWarnings")
 	for _, lang := range bndl.LanguageTags() {
 		currentLang := lang
 
@@ -85,22 +88,23 @@ func (t Translator) initFuncs(bndl *bundle.Bundle) {
 				return translated
 			}
 
-			if t.cfg.GetBool("logI18nWarnings") {
-				i18nWarningLogger.Printf("i18n|MISSING_TRANSLATION|%s|%s", currentLang, translationID)
-			}
+			// Find the text to use instead, and where it comes from.
+			source := "none"
+			translated = ""
 			if enableMissingTranslationPlaceholders {
-				return "[i18n] " + translationID
-			}
-			if defaultT != nil {
-				translated := defaultT(translationID, args...)
-				if translated != translationID {
-					return translated
-				}
-				if isIDTranslated(defaultContentLanguage, translationID, bndl) {
-					return translated
+				source, translated = "placeholder", "[i18n] "+translationID
+			} else if defaultT != nil {
+				if dt := defaultT(translationID, args...); dt != translationID || isIDTranslated(defaultContentLanguage, translationID, bndl) {
+					source, translated = defaultContentLanguage, dt
 				}
 			}
-			return ""
+
+			if logI18nWarnings {
+				t.warnings.Printf(i18nWarningLogger, helpers.WarningMissingTranslation,
+					"i18n|MISSING_TRANSLATION|%s|%s|%s", currentLang, translationID, source)
+			}
+
+			return translated
 		}
 	}
 }
@@ -14,9 +14,13 @@
 package i18n
 
 import (
+	"bytes"
 	"path/filepath"
 	"testing"
 
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/nicksnyder/go-i18n/i18n/bundle"
+
 	"github.com/gohugoio/hugo/tpl/tplimpl"
 
 	"github.com/gohugoio/hugo/langs"
@@ -224,3 +228,37 @@ func TestI18nTranslate(t *testing.T) {
 		}
 	}
 }
+
+func TestI18nMissingTranslationWarnings(t *testing.T) {
+	assert := require.New(t)
+
+	var logBuf bytes.Buffer
+	defer func(l *helpers.DistinctLogger) { i18nWarningLogger = l }(i18nWarningLogger)
+
+	bndl := bundle.New()
+	assert.NoError(bndl.ParseTranslationFileBytes("en.toml", []byte("[hello]\nother = \"Hello\"")))
+	assert.NoError(bndl.ParseTranslationFileBytes("fr.toml", []byte("[bye]\nother = \"Au revoir\"")))
+
+	for _, logWarnings := range []bool{false, true} {
+		logBuf.Reset()
+		i18nWarningLogger = helpers.NewDistinctLogger(log.New(&logBuf, "", 0))
+
+		v := viper.New()
+		v.Set("defaultContentLanguage", "en")
+		v.Set("logI18nWarnings", logWarnings)
+		warnings := helpers.NewWarningCounter()
+
+		tr := NewTranslator(bndl, v, logger, warnings)
+		assert.Equal("Hello", tr.Func("fr")("hello"))
+		assert.Equal("", tr.Func("fr")("missing"))
+
+		if !logWarnings {
+			assert.Equal(0, warnings.Count(helpers.WarningMissingTranslation))
+			assert.Empty(logBuf.String())
+			continue
+		}
+
+		assert.Equal(2, warnings.Count(helpers.WarningMissingTranslation))
+		assert.Equal("i18n|MISSING_TRANSLATION|fr|hello|en\ni18n|MISSING_TRANSLATION|fr|missing|none\n", logBuf.String())
+	}
+}
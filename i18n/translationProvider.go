@@ -71,7 +71,7 @@ func (tp *TranslationProvider) Update(d *deps.Deps) error {
 		}
 	}
 
-	tp.t = NewTranslator(i18nBundle, d.Cfg, d.Log)
+	tp.t = NewTranslator(i18nBundle, d.Cfg, d.Log, d.Warnings)
 
 	d.Translate = tp.t.Func(d.Language.Lang)
 
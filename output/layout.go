@@ -178,6 +178,10 @@ func resolvePageTemplate(d LayoutDescriptor, f Format) []string {
 		layouts = append(layouts, "_internal/_default/rss.xml")
 	}
 
+	if f.Name == SearchIndexFormat.Name {
+		layouts = append(layouts, "_internal/_default/searchindex.json")
+	}
+
 	return layouts
 
 }
@@ -105,6 +105,8 @@ func TestLayout(t *testing.T) {
 			[]string{"_text/index.json.json", "_text/home.json.json"}, 12},
 		{"Page plain text", LayoutDescriptor{Kind: "page"}, "", JSONFormat,
 			[]string{"_text/_default/single.json.json", "_text/_default/single.json"}, 2},
+		{"Search index home", LayoutDescriptor{Kind: "home"}, "", SearchIndexFormat,
+			[]string{"_text/index.searchindex.json", "_text/home.searchindex.json"}, 13},
 		{"Reserved section, shortcodes", LayoutDescriptor{Kind: "section", Section: "shortcodes", Type: "shortcodes"}, "", ampType,
 			[]string{"section/shortcodes.amp.html"}, 12},
 		{"Reserved section, partials", LayoutDescriptor{Kind: "section", Section: "partials", Type: "partials"}, "", ampType,
@@ -53,6 +53,10 @@ type Format struct {
 	// The protocol to use, i.e. "webcal://". Defaults to the protocol of the baseURL.
 	Protocol string `json:"protocol"`
 
+	// The path element used in paginator URLs for this format, i.e. "page" in
+	// "/page/2/". Defaults to the site's paginatePath.
+	PaginatePath string `json:"paginatePath"`
+
 	// IsPlainText decides whether to use text/template or html/template
 	// as template parser.
 	IsPlainText bool `json:"isPlainText"`
@@ -61,6 +65,10 @@ type Format struct {
 	// HTML, AMP etc. This is used to decide when to create alias redirects etc.
 	IsHTML bool `json:"isHTML"`
 
+	// Enable to paginate a format outside of the HTML family, e.g. a JSON
+	// search index. HTML formats are always paginated.
+	Paginate bool `json:"paginate"`
+
 	// Enable to ignore the global uglyURLs setting.
 	NoUgly bool `json:"noUgly"`
 
@@ -141,6 +149,17 @@ var (
 		Rel:       "alternate",
 	}
 
+	// SearchIndexFormat is a JSON index of the site's regular pages that can be
+	// used for client side search with e.g. Lunr.js or Fuse.js.
+	SearchIndexFormat = Format{
+		Name:           "SearchIndex",
+		MediaType:      media.JSONType,
+		BaseName:       "searchindex",
+		IsPlainText:    true,
+		Rel:            "alternate",
+		NotAlternative: true,
+	}
+
 	SitemapFormat = Format{
 		Name:      "Sitemap",
 		MediaType: media.XMLType,
@@ -159,6 +178,7 @@ var DefaultFormats = Formats{
 	JSONFormat,
 	RobotsTxtFormat,
 	RSSFormat,
+	SearchIndexFormat,
 	SitemapFormat,
 }
 
@@ -16,6 +16,7 @@ package resource
 import (
 	"errors"
 	"fmt"
+	"html/template"
 	"image/color"
 	"io"
 	"os"
@@ -29,7 +30,7 @@ import (
 	// Importing image codecs for image.DecodeConfig
 	"image"
 	"image/draw"
-	_ "image/gif"
+	"image/gif"
 	"image/jpeg"
 	_ "image/png"
 
@@ -79,6 +80,7 @@ var (
 	// re-generation.
 	imageFormatsVersions = map[imaging.Format]int{
 		imaging.PNG: 2, // Floyd Steinberg dithering
+		imaging.GIF: 1, // Animated GIFs
 	}
 
 	// Increment to mark all processed images as stale. Only use when absolutely needed.
@@ -130,6 +132,17 @@ type Image struct {
 
 	format imaging.Format
 
+	// Set for the processed versions of the original image.
+	processed bool
+
+	colors     []string
+	colorsInit sync.Once
+	colorsErr  error
+
+	lqip     template.URL
+	lqipInit sync.Once
+	lqipErr  error
+
 	*genericResource
 }
 
@@ -161,9 +174,25 @@ func (i *Image) Resize(spec string) (*Image, error) {
 }
 
 // Fit scales down the image using the specified resample filter to fit the specified
-// maximum width and height.
+// maximum width and height. Fit never scales up, so if the original image already
+// fits, and no rotation or quality is set, the image itself is returned.
 func (i *Image) Fit(spec string) (*Image, error) {
-	return i.doWithImageConfig("fit", spec, func(src image.Image, conf imageConfig) (image.Image, error) {
+	conf, err := parseImageConfig(spec)
+	if err != nil {
+		return nil, err
+	}
+	conf.Action = "fit"
+
+	if !i.processed && conf.Rotate == 0 && conf.Quality == 0 && conf.Width > 0 && conf.Height > 0 {
+		if err := i.initConfig(); err != nil {
+			return nil, err
+		}
+		if i.config.Width <= conf.Width && i.config.Height <= conf.Height {
+			return i, nil
+		}
+	}
+
+	return i.doWithConfig(conf, func(src image.Image, conf imageConfig) (image.Image, error) {
 		return imaging.Fit(src, conf.Width, conf.Height, conf.Filter), nil
 	})
 }
@@ -180,6 +209,44 @@ func (i *Image) Fill(spec string) (*Image, error) {
 	})
 }
 
+// Srcset resizes the image to each of the given widths and returns a srcset
+// attribute value listing them, e.g. "/a/sunset_hu...jpg 300w, /a/sunset.jpg 320w".
+// Widths larger than the image are skipped. If none of the widths are valid,
+// the image itself is listed.
+func (i *Image) Srcset(widths ...int) (string, error) {
+	if err := i.initConfig(); err != nil {
+		return "", err
+	}
+
+	var candidates []string
+
+	for _, w := range widths {
+		if w <= 0 {
+			return "", fmt.Errorf("invalid srcset width %d", w)
+		}
+		if w > i.config.Width {
+			continue
+		}
+
+		img := i
+		if w < i.config.Width {
+			var err error
+			img, err = i.Resize(strconv.Itoa(w) + "x")
+			if err != nil {
+				return "", err
+			}
+		}
+
+		candidates = append(candidates, fmt.Sprintf("%s %dw", img.RelPermalink(), w))
+	}
+
+	if len(candidates) == 0 {
+		candidates = append(candidates, fmt.Sprintf("%s %dw", i.RelPermalink(), i.config.Width))
+	}
+
+	return strings.Join(candidates, ", "), nil
+}
+
 // Holds configuration to create a new image from an existing one, resize etc.
 type imageConfig struct {
 	Action string
@@ -201,6 +268,9 @@ type imageConfig struct {
 
 	Anchor    imaging.Anchor
 	AnchorStr string
+
+	// FiltersKey identifies the filters applied with Filter, if any.
+	FiltersKey string
 }
 
 func (i *Image) isJPEG() bool {
@@ -215,6 +285,10 @@ func (i *Image) doWithImageConfig(action, spec string, f func(src image.Image, c
 	}
 	conf.Action = action
 
+	return i.doWithConfig(conf, f)
+}
+
+func (i *Image) doWithConfig(conf imageConfig, f func(src image.Image, conf imageConfig) (image.Image, error)) (*Image, error) {
 	if conf.Quality <= 0 && i.isJPEG() {
 		// We need a quality setting for all JPEGs
 		conf.Quality = i.imaging.Quality
@@ -235,14 +309,42 @@ func (i *Image) doWithImageConfig(action, spec string, f func(src image.Image, c
 	return i.spec.imageCache.getOrCreate(i, conf, func(resourceCacheFilename string) (*Image, error) {
 		ci := i.clone()
 
-		errOp := action
+		errOp := conf.Action
 		errPath := i.sourceFilename
 
 		ci.setBasePath(conf)
 
-		src, err := i.decodeSource()
-		if err != nil {
-			return nil, &os.PathError{Op: errOp, Path: errPath, Err: err}
+		var src image.Image
+
+		if i.format == imaging.GIF {
+			anim, err := i.decodeGIF()
+			if err != nil {
+				return nil, &os.PathError{Op: errOp, Path: errPath, Err: err}
+			}
+
+			if len(anim.Image) > 1 {
+				// Process every frame to preserve the animation.
+				anim, err = transformGIF(anim, conf, f)
+				if err != nil {
+					return ci, &os.PathError{Op: errOp, Path: errPath, Err: err}
+				}
+
+				ci.config = image.Config{Width: anim.Config.Width, Height: anim.Config.Height}
+				ci.configLoaded = true
+
+				return ci, i.writeToDestinations(resourceCacheFilename, ci.targetFilename(), func(w io.Writer) error {
+					return gif.EncodeAll(w, anim)
+				})
+			}
+
+			// A still GIF, no need to decode it again.
+			src = anim.Image[0]
+		} else {
+			var err error
+			src, err = i.decodeSource()
+			if err != nil {
+				return nil, &os.PathError{Op: errOp, Path: errPath, Err: err}
+			}
 		}
 
 		if conf.Rotate != 0 {
@@ -295,6 +397,10 @@ func (i imageConfig) key(format imaging.Format) string {
 		k += "_" + anchor
 	}
 
+	if i.FiltersKey != "" {
+		k += "_" + helpers.MD5String(i.FiltersKey)
+	}
+
 	if v, ok := imageFormatsVersions[format]; ok {
 		k += "_" + strconv.Itoa(v)
 	}
@@ -486,6 +592,36 @@ func (i *Image) copyToDestination(src string) error {
 }
 
 func (i *Image) encodeToDestinations(img image.Image, conf imageConfig, resourceCacheFilename, targetFilename string) error {
+	return i.writeToDestinations(resourceCacheFilename, targetFilename, func(w io.Writer) error {
+		switch i.format {
+		case imaging.JPEG:
+
+			var rgba *image.RGBA
+			quality := conf.Quality
+
+			if nrgba, ok := img.(*image.NRGBA); ok {
+				if nrgba.Opaque() {
+					rgba = &image.RGBA{
+						Pix:    nrgba.Pix,
+						Stride: nrgba.Stride,
+						Rect:   nrgba.Rect,
+					}
+				}
+			}
+			if rgba != nil {
+				return jpeg.Encode(w, rgba, &jpeg.Options{Quality: quality})
+			} else {
+				return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+			}
+		default:
+			return imaging.Encode(w, img, i.format)
+		}
+	})
+}
+
+// writeToDestinations writes the processed image to the target in the publish
+// folder and, if resourceCacheFilename is set, to the image resource cache.
+func (i *Image) writeToDestinations(resourceCacheFilename, targetFilename string, write func(w io.Writer) error) error {
 
 	file1, err := openFileForWriting(i.spec.BaseFs.PublishFs, targetFilename)
 	if err != nil {
@@ -509,30 +645,7 @@ func (i *Image) encodeToDestinations(img image.Image, conf imageConfig, resource
 		w = file1
 	}
 
-	switch i.format {
-	case imaging.JPEG:
-
-		var rgba *image.RGBA
-		quality := conf.Quality
-
-		if nrgba, ok := img.(*image.NRGBA); ok {
-			if nrgba.Opaque() {
-				rgba = &image.RGBA{
-					Pix:    nrgba.Pix,
-					Stride: nrgba.Stride,
-					Rect:   nrgba.Rect,
-				}
-			}
-		}
-		if rgba != nil {
-			return jpeg.Encode(w, rgba, &jpeg.Options{Quality: quality})
-		} else {
-			return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
-		}
-	default:
-		return imaging.Encode(w, img, i.format)
-	}
-
+	return write(w)
 }
 
 func (i *Image) clone() *Image {
@@ -542,6 +655,7 @@ func (i *Image) clone() *Image {
 	return &Image{
 		imaging:         i.imaging,
 		format:          i.format,
+		processed:       true,
 		genericResource: &g}
 }
 
@@ -0,0 +1,140 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sort"
+
+	"github.com/disintegration/imaging"
+)
+
+const (
+	// The default number of colors returned by Colors.
+	dominantColorsCount = 5
+
+	// Images are scaled down to this size before the colors are counted.
+	dominantColorsSampleSize = 64
+)
+
+// Colors returns the most dominant colors in the image as hex strings, e.g.
+// "#ff0000", the most dominant first. It returns at most 5 colors, or at most
+// the given count. The colors are counted once per image.
+func (i *Image) Colors(count ...int) ([]string, error) {
+	n := dominantColorsCount
+	if len(count) > 0 {
+		n = count[0]
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("invalid number of colors %d for image %q", n, i.sourceFilename)
+	}
+
+	i.colorsInit.Do(func() {
+		var src image.Image
+		src, i.colorsErr = i.decodeSource()
+		if i.colorsErr != nil {
+			i.colorsErr = fmt.Errorf("failed to decode image %q: %s", i.sourceFilename, i.colorsErr)
+			return
+		}
+		// Keep all of them, so any count can be served from the cache.
+		i.colors = dominantColors(src, -1)
+	})
+
+	if i.colorsErr != nil {
+		return nil, i.colorsErr
+	}
+
+	if len(i.colors) > n {
+		return i.colors[:n:n], nil
+	}
+
+	return i.colors, nil
+}
+
+type colorBucket struct {
+	count   int
+	r, g, b int
+}
+
+// dominantColors quantizes the colors in src into buckets with 4 bits per
+// channel and returns the average colors of the n most populated buckets, or
+// of all of them if n is negative.
+func dominantColors(src image.Image, n int) []string {
+	b := src.Bounds()
+	if b.Dx() > dominantColorsSampleSize || b.Dy() > dominantColorsSampleSize {
+		src = imaging.Fit(src, dominantColorsSampleSize, dominantColorsSampleSize, imaging.Box)
+		b = src.Bounds()
+	}
+
+	buckets := make(map[uint16]*colorBucket)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(src.At(x, y)).(color.NRGBA)
+			if c.A < 0x80 {
+				// Ignore the (mostly) transparent parts.
+				continue
+			}
+			key := uint16(c.R>>4)<<8 | uint16(c.G>>4)<<4 | uint16(c.B>>4)
+			bucket, found := buckets[key]
+			if !found {
+				bucket = &colorBucket{}
+				buckets[key] = bucket
+			}
+			bucket.count++
+			bucket.r += int(c.R)
+			bucket.g += int(c.G)
+			bucket.b += int(c.B)
+		}
+	}
+
+	sorted := make([]*colorBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		sorted = append(sorted, bucket)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].count != sorted[j].count {
+			return sorted[i].count > sorted[j].count
+		}
+		// Make the order stable.
+		return colorToHexString(sorted[i].average()) < colorToHexString(sorted[j].average())
+	})
+
+	if n >= 0 && len(sorted) > n {
+		sorted = sorted[:n]
+	}
+
+	colors := make([]string, len(sorted))
+	for i, bucket := range sorted {
+		colors[i] = colorToHexString(bucket.average())
+	}
+
+	return colors
+}
+
+func (b *colorBucket) average() color.NRGBA {
+	return color.NRGBA{
+		R: uint8(b.r / b.count),
+		G: uint8(b.g / b.count),
+		B: uint8(b.b / b.count),
+		A: 0xff,
+	}
+}
+
+func colorToHexString(c color.NRGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,265 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strconv"
+	"strings"
+
+	"github.com/gohugoio/hugo/helpers"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// ImageFilter is an effect that can be applied to an image with Filter.
+type ImageFilter interface {
+	// Key identifies the filter and its options. It is used to name the
+	// processed image.
+	Key() string
+
+	// Apply applies the filter to src.
+	Apply(src image.Image) (image.Image, error)
+}
+
+// Filter applies the given filters, in order, to the image. The image
+// dimensions are preserved unless a filter changes them.
+func (i *Image) Filter(filters ...ImageFilter) (*Image, error) {
+	if len(filters) == 0 {
+		return nil, errors.New("must provide one or more filters")
+	}
+
+	keys := make([]string, len(filters))
+	for j, filter := range filters {
+		keys[j] = filter.Key()
+	}
+
+	conf := imageConfig{Action: "filter", FiltersKey: strings.Join(keys, "|")}
+
+	return i.doWithConfig(conf, func(src image.Image, conf imageConfig) (image.Image, error) {
+		var err error
+		for _, filter := range filters {
+			src, err = filter.Apply(src)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return src, nil
+	})
+}
+
+// TextOptions configures the text filter.
+type TextOptions struct {
+	// The position of the top left corner of the text, in pixels.
+	X int
+	Y int
+
+	// The font size in pixels. Default is 20.
+	Size float64
+
+	// The text color as a hex string, e.g. "#fff". Default is black.
+	Color string
+
+	// The TrueType or OpenType font to use. Default is Go Regular.
+	Font []byte
+}
+
+const defaultTextSize = 20
+
+type textFilter struct {
+	text  string
+	opts  TextOptions
+	font  *sfnt.Font
+	color color.Color
+}
+
+// NewTextFilter creates a filter that draws the given text onto an image.
+func NewTextFilter(text string, opts TextOptions) (ImageFilter, error) {
+	if opts.Size <= 0 {
+		opts.Size = defaultTextSize
+	}
+
+	fontData := opts.Font
+	if fontData == nil {
+		fontData = goregular.TTF
+	}
+
+	f, err := sfnt.Parse(fontData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse font: %s", err)
+	}
+
+	c := color.Color(color.Black)
+	if opts.Color != "" {
+		c, err = hexStringToColor(opts.Color)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &textFilter{text: text, opts: opts, font: f, color: c}, nil
+}
+
+func (f *textFilter) Key() string {
+	k := fmt.Sprintf("text_%s_%d_%d_%g_%s", f.text, f.opts.X, f.opts.Y, f.opts.Size, f.opts.Color)
+	if f.opts.Font != nil {
+		k += "_" + helpers.MD5String(string(f.opts.Font))
+	}
+	return k
+}
+
+func (f *textFilter) Apply(src image.Image) (image.Image, error) {
+	var (
+		b    sfnt.Buffer
+		ppem = fixed.Int26_6(f.opts.Size * 64)
+	)
+
+	metrics, err := f.font.Metrics(&b, ppem, font.HintingNone)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	r := vector.NewRasterizer(bounds.Dx(), bounds.Dy())
+	r.DrawOp = draw.Src
+
+	x := float32(f.opts.X)
+	y := float32(f.opts.Y) + float32(metrics.Ascent)/64
+
+	prev := sfnt.GlyphIndex(0)
+	for _, c := range f.text {
+		idx, err := f.font.GlyphIndex(&b, c)
+		if err != nil {
+			return nil, err
+		}
+
+		if prev != 0 {
+			if kern, err := f.font.Kern(&b, prev, idx, ppem, font.HintingNone); err == nil {
+				x += float32(kern) / 64
+			}
+		}
+		prev = idx
+
+		segments, err := f.font.LoadGlyph(&b, idx, ppem, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, seg := range segments {
+			// The segment args are 26.6 fixed point numbers relative to the
+			// glyph's origin.
+			p := func(i int) (float32, float32) {
+				return x + float32(seg.Args[i].X)/64, y + float32(seg.Args[i].Y)/64
+			}
+			switch seg.Op {
+			case sfnt.SegmentOpMoveTo:
+				r.MoveTo(p(0))
+			case sfnt.SegmentOpLineTo:
+				r.LineTo(p(0))
+			case sfnt.SegmentOpQuadTo:
+				x1, y1 := p(0)
+				x2, y2 := p(1)
+				r.QuadTo(x1, y1, x2, y2)
+			case sfnt.SegmentOpCubeTo:
+				x1, y1 := p(0)
+				x2, y2 := p(1)
+				x3, y3 := p(2)
+				r.CubeTo(x1, y1, x2, y2, x3, y3)
+			}
+		}
+
+		advance, err := f.font.GlyphAdvance(&b, idx, ppem, font.HintingNone)
+		if err != nil {
+			return nil, err
+		}
+		x += float32(advance) / 64
+	}
+
+	mask := image.NewAlpha(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	r.Draw(mask, mask.Bounds(), image.Opaque, image.Point{})
+
+	dst := image.NewNRGBA(bounds)
+	draw.Draw(dst, bounds, src, bounds.Min, draw.Src)
+	draw.DrawMask(dst, bounds, image.NewUniform(f.color), image.Point{}, mask, image.Point{}, draw.Over)
+
+	return dst, nil
+}
+
+type overlayFilter struct {
+	src  *Image
+	x, y int
+}
+
+// NewOverlayFilter creates a filter that draws src on top of an image with
+// its top left corner at the given position. Any part of src that falls
+// outside of the image is clipped.
+func NewOverlayFilter(src *Image, x, y int) ImageFilter {
+	return &overlayFilter{src: src, x: x, y: y}
+}
+
+func (f *overlayFilter) Key() string {
+	hash := ""
+	if err := f.src.initHash(); err == nil {
+		hash = f.src.hash
+	}
+	return fmt.Sprintf("overlay_%s_%s_%d_%d", f.src.relTargetDirFile.path(), hash, f.x, f.y)
+}
+
+func (f *overlayFilter) Apply(src image.Image) (image.Image, error) {
+	overlay, err := f.src.decodeSource()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode overlay image: %s", err)
+	}
+
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(bounds)
+	draw.Draw(dst, bounds, src, bounds.Min, draw.Src)
+
+	ob := overlay.Bounds()
+	r := image.Rect(0, 0, ob.Dx(), ob.Dy()).Add(bounds.Min).Add(image.Pt(f.x, f.y))
+	draw.Draw(dst, r, overlay, ob.Min, draw.Over)
+
+	return dst, nil
+}
+
+// hexStringToColor parses colors on the form #rgb, #rrggbb or #rrggbbaa.
+func hexStringToColor(str string) (color.Color, error) {
+	s := strings.TrimPrefix(str, "#")
+
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) == 6 {
+		s += "ff"
+	}
+
+	if len(s) != 8 {
+		return nil, fmt.Errorf("invalid color %q", str)
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color %q", str)
+	}
+
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
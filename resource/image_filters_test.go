@@ -0,0 +1,147 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageFilterText(t *testing.T) {
+	assert := require.New(t)
+
+	img := fetchSunset(assert)
+
+	filter, err := NewTextFilter("Hello", TextOptions{X: 10, Y: 10, Size: 24, Color: "#fff"})
+	assert.NoError(err)
+
+	filtered, err := img.Filter(filter)
+	assert.NoError(err)
+	assert.Equal(img.Width(), filtered.Width())
+	assert.Equal(img.Height(), filtered.Height())
+	assert.Contains(filtered.RelPermalink(), "_filter_")
+	assertFileCache(assert, img.spec.BaseFs.Resources.Fs, filtered.RelPermalink(), img.Width(), img.Height())
+
+	src, err := img.decodeSource()
+	assert.NoError(err)
+	dst := decodeImageFile(assert, img.spec.BaseFs.Resources.Fs, filepath.Join("_gen/images", filtered.RelPermalink()))
+
+	// The text is white, so there should be a lot more white pixels in the text area.
+	textArea := image.Rect(10, 10, 80, 40)
+	assert.True(countWhitePixels(dst, textArea) > countWhitePixels(src, textArea)+50)
+
+	// Same filter should give the same image.
+	filteredAgain, err := img.Filter(filter)
+	assert.NoError(err)
+	assert.True(filtered == filteredAgain)
+
+	// Different text should give a different image.
+	filter2, err := NewTextFilter("World", TextOptions{X: 10, Y: 10, Size: 24, Color: "#fff"})
+	assert.NoError(err)
+	filtered2, err := img.Filter(filter2)
+	assert.NoError(err)
+	assert.NotEqual(filtered.RelPermalink(), filtered2.RelPermalink())
+
+	_, err = img.Filter()
+	assert.Error(err)
+
+	_, err = NewTextFilter("Hello", TextOptions{Color: "#ffff"})
+	assert.Error(err)
+
+	_, err = NewTextFilter("Hello", TextOptions{Font: []byte("not a font")})
+	assert.Error(err)
+}
+
+func TestImageFilterOverlay(t *testing.T) {
+	assert := require.New(t)
+
+	spec := newTestResourceSpec(assert)
+	base := fetchImageForSpec(spec, assert, "sunset.jpg")
+	red := fetchImageForSpec(spec, assert, "red.png")
+	large := fetchImageForSpec(spec, assert, "gohugoio.png")
+
+	filtered, err := base.Filter(NewOverlayFilter(red, 10, 10))
+	assert.NoError(err)
+	assert.Equal(base.Width(), filtered.Width())
+	assert.Equal(base.Height(), filtered.Height())
+
+	dst := decodeImageFile(assert, spec.BaseFs.Resources.Fs, filepath.Join("_gen/images", filtered.RelPermalink()))
+	r, g, b, _ := dst.At(20, 20).RGBA()
+	assert.True(r > 0xe000 && g < 0x2000 && b < 0x2000, "expected red, got %d %d %d", r, g, b)
+
+	// Move it and we get a new image.
+	moved, err := base.Filter(NewOverlayFilter(red, 20, 10))
+	assert.NoError(err)
+	assert.NotEqual(filtered.RelPermalink(), moved.RelPermalink())
+
+	// Overlays larger than the base image or outside of it are clipped.
+	clipped, err := base.Filter(NewOverlayFilter(large, -10, -10))
+	assert.NoError(err)
+	assert.Equal(base.Width(), clipped.Width())
+	assert.Equal(base.Height(), clipped.Height())
+
+	clipped, err = base.Filter(NewOverlayFilter(red, base.Width()-5, base.Height()-5))
+	assert.NoError(err)
+	assert.Equal(base.Width(), clipped.Width())
+	assert.Equal(base.Height(), clipped.Height())
+}
+
+func TestHexStringToColor(t *testing.T) {
+	assert := require.New(t)
+
+	for _, test := range []struct {
+		in     string
+		expect color.Color
+	}{
+		{"#fff", color.NRGBA{R: 255, G: 255, B: 255, A: 255}},
+		{"#ff0000", color.NRGBA{R: 255, A: 255}},
+		{"00ff0080", color.NRGBA{G: 255, A: 128}},
+	} {
+		c, err := hexStringToColor(test.in)
+		assert.NoError(err)
+		assert.Equal(test.expect, c, test.in)
+	}
+
+	_, err := hexStringToColor("#ggg")
+	assert.Error(err)
+}
+
+func decodeImageFile(assert *require.Assertions, fs afero.Fs, filename string) image.Image {
+	f, err := fs.Open(filename)
+	assert.NoError(err)
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	assert.NoError(err)
+
+	return img
+}
+
+func countWhitePixels(img image.Image, area image.Rectangle) int {
+	count := 0
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			if r > 0xf000 && g > 0xf000 && b > 0xf000 {
+				count++
+			}
+		}
+	}
+	return count
+}
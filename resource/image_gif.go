@@ -0,0 +1,110 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+
+	"github.com/disintegration/imaging"
+)
+
+func (i *Image) decodeGIF() (*gif.GIF, error) {
+	f, err := i.ReadSeekCloser()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image for decode: %s", err)
+	}
+	defer f.Close()
+	return gif.DecodeAll(f)
+}
+
+// transformGIF applies f to every frame of the animated GIF src.
+// The frames in a GIF may only cover parts of the image and depend on the
+// frames before them, so we first compose the full image for every frame,
+// transform it and then store it as a frame that replaces the previous one.
+// A smart crop is found from the first frame and applied to all of them.
+func transformGIF(src *gif.GIF, conf imageConfig, f func(src image.Image, conf imageConfig) (image.Image, error)) (*gif.GIF, error) {
+	canvas := image.NewRGBA(image.Rect(0, 0, src.Config.Width, src.Config.Height))
+
+	dst := &gif.GIF{
+		Image:           make([]*image.Paletted, len(src.Image)),
+		Delay:           src.Delay,
+		LoopCount:       src.LoopCount,
+		Disposal:        make([]byte, len(src.Image)),
+		Config:          src.Config,
+		BackgroundIndex: src.BackgroundIndex,
+	}
+
+	for j, frame := range src.Image {
+		var disposal byte
+		if j < len(src.Disposal) {
+			disposal = src.Disposal[j]
+		}
+
+		var previous *image.RGBA
+		if disposal == gif.DisposalPrevious {
+			previous = image.NewRGBA(canvas.Bounds())
+			draw.Draw(previous, previous.Bounds(), canvas, image.Point{}, draw.Src)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+
+		var composed image.Image = image.NewRGBA(canvas.Bounds())
+		draw.Draw(composed.(*image.RGBA), canvas.Bounds(), canvas, image.Point{}, draw.Src)
+
+		if conf.Rotate != 0 {
+			composed = imaging.Rotate(composed, float64(conf.Rotate), color.Transparent)
+		}
+
+		if j == 0 && conf.Action == "fill" && conf.AnchorStr == smartCropIdentifier && conf.Width > 0 && conf.Height > 0 {
+			// Find the crop once and use it for all the frames, or the
+			// animation will jitter.
+			rect, err := smartCropRect(composed, conf.Width, conf.Height, conf.Filter)
+			if err != nil {
+				return nil, err
+			}
+			f = func(src image.Image, conf imageConfig) (image.Image, error) {
+				return cropAndResize(src, rect, conf.Width, conf.Height, conf.Filter), nil
+			}
+		}
+
+		converted, err := f(composed, conf)
+		if err != nil {
+			return nil, err
+		}
+
+		b := converted.Bounds()
+		paletted := image.NewPaletted(image.Rect(0, 0, b.Dx(), b.Dy()), frame.Palette)
+		draw.FloydSteinberg.Draw(paletted, paletted.Bounds(), converted, b.Min)
+
+		dst.Image[j] = paletted
+		dst.Disposal[j] = gif.DisposalBackground
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+
+	b := dst.Image[0].Bounds()
+	dst.Config.Width = b.Dx()
+	dst.Config.Height = b.Dy()
+
+	return dst, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/disintegration/imaging"
+)
+
+const (
+	// The max width and height of the placeholder image.
+	lqipSize = 16
+
+	lqipBlurSigma   = 0.8
+	lqipJPEGQuality = 50
+)
+
+// LQIP returns a low quality image placeholder, a tiny and blurred version
+// of the image as a base64 encoded data URI, to show while the full image
+// loads. The result is cached per image.
+func (i *Image) LQIP() (template.URL, error) {
+	i.lqipInit.Do(func() {
+		var src image.Image
+		src, i.lqipErr = i.decodeSource()
+		if i.lqipErr != nil {
+			i.lqipErr = fmt.Errorf("failed to decode image %q: %s", i.sourceFilename, i.lqipErr)
+			return
+		}
+
+		i.lqip, i.lqipErr = i.createLQIP(src)
+	})
+
+	return i.lqip, i.lqipErr
+}
+
+func (i *Image) createLQIP(src image.Image) (template.URL, error) {
+	placeholder := imaging.Blur(imaging.Fit(src, lqipSize, lqipSize, imaging.Box), lqipBlurSigma)
+
+	var (
+		buf       bytes.Buffer
+		mediaType string
+		err       error
+	)
+
+	switch i.format {
+	case imaging.PNG, imaging.GIF:
+		// Keep any transparency.
+		mediaType = "image/png"
+		err = png.Encode(&buf, placeholder)
+	default:
+		mediaType = "image/jpeg"
+		err = jpeg.Encode(&buf, placeholder, &jpeg.Options{Quality: lqipJPEGQuality})
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return template.URL("data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(buf.Bytes())), nil
+}
@@ -15,10 +15,15 @@ package resource
 
 import (
 	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/disintegration/imaging"
@@ -132,6 +137,49 @@ func TestImageTransformBasic(t *testing.T) {
 
 }
 
+func TestImageFitNoUpscale(t *testing.T) {
+
+	assert := require.New(t)
+
+	image := fetchImage(assert, "red.png")
+
+	fitted, err := image.Fit("500x500")
+	assert.NoError(err)
+	assert.True(image == fitted)
+	assert.Equal(20, fitted.Width())
+	assert.Equal(20, fitted.Height())
+	assert.Equal("/a/red.png", fitted.RelPermalink())
+
+	// Exactly the source size.
+	fitted, err = image.Fit("20x20")
+	assert.NoError(err)
+	assert.True(image == fitted)
+
+	// One dimension too small.
+	fitted, err = image.Fit("500x10")
+	assert.NoError(err)
+	assert.Equal(10, fitted.Width())
+	assert.Equal(10, fitted.Height())
+
+	// Rotation needs processing.
+	fitted, err = image.Fit("500x500 r90")
+	assert.NoError(err)
+	assert.False(image == fitted)
+	assert.Equal(20, fitted.Width())
+
+	// Only the original is returned as is. A processed image that already
+	// fits is processed again, with its key past the MD5 key threshold.
+	fitted, err = image.Fit("500x10")
+	assert.NoError(err)
+	fittedAgain, err := fitted.Fit("500x10")
+	assert.NoError(err)
+	assert.False(fitted == fittedAgain)
+	assert.Equal(10, fittedAgain.Width())
+	assert.Equal(10, fittedAgain.Height())
+	assert.Regexp(`^/a/red_hu[0-9a-f]+_\d+_[0-9a-f]{32}\.png$`, fittedAgain.RelPermalink())
+
+}
+
 // https://github.com/gohugoio/hugo/issues/4261
 func TestImageTransformLongFilename(t *testing.T) {
 	assert := require.New(t)
@@ -282,6 +330,91 @@ func TestImageResize8BitPNG(t *testing.T) {
 
 }
 
+func TestImageResizeAnimatedGIF(t *testing.T) {
+
+	assert := require.New(t)
+
+	image := fetchImage(assert, "animated.gif")
+
+	assert.Equal(imaging.GIF, image.format)
+	assert.Equal(40, image.Width())
+	assert.Equal(30, image.Height())
+
+	resized, err := image.Resize("20x")
+	assert.NoError(err)
+	assert.Equal(imaging.GIF, resized.format)
+	assert.Equal(20, resized.Width())
+	assert.Equal(15, resized.Height())
+
+	f, err := image.spec.BaseFs.Resources.Fs.Open(filepath.Join("_gen/images", resized.RelPermalink()))
+	assert.NoError(err)
+	defer f.Close()
+
+	anim, err := gif.DecodeAll(f)
+	assert.NoError(err)
+	assert.Len(anim.Image, 3)
+	assert.Equal(20, anim.Config.Width)
+	assert.Equal(15, anim.Config.Height)
+
+	for _, frame := range anim.Image {
+		assert.Equal(20, frame.Bounds().Dx())
+		assert.Equal(15, frame.Bounds().Dy())
+	}
+
+	// The second frame only covers the center of the image, the rest should
+	// be filled in from the first.
+	r, g, _, _ := anim.Image[1].At(10, 7).RGBA()
+	assert.True(g > 0xe000 && r < 0x2000)
+	r, g, _, _ = anim.Image[1].At(1, 1).RGBA()
+	assert.True(r > 0xe000 && g < 0x2000)
+
+}
+
+func TestTransformGIFSmartCropsAllFramesTheSame(t *testing.T) {
+	assert := require.New(t)
+
+	const (
+		red = iota
+		gray
+	)
+
+	palette := color.Palette{color.RGBA{R: 0xff, A: 0xff}, color.Gray{Y: 0x80}}
+
+	// A gray frame with a red square, which the smart crop will go for.
+	newFrame := func(square image.Rectangle) *image.Paletted {
+		frame := image.NewPaletted(image.Rect(0, 0, 80, 40), palette)
+		draw.Draw(frame, frame.Bounds(), image.NewUniform(palette[gray]), image.Point{}, draw.Src)
+		draw.Draw(frame, square, image.NewUniform(palette[red]), image.Point{}, draw.Src)
+		return frame
+	}
+
+	src := &gif.GIF{
+		Image:  []*image.Paletted{newFrame(image.Rect(0, 10, 20, 30)), newFrame(image.Rect(60, 10, 80, 30))},
+		Delay:  []int{10, 10},
+		Config: image.Config{Width: 80, Height: 40},
+	}
+
+	conf := imageConfig{Action: "fill", Width: 40, Height: 40, AnchorStr: smartCropIdentifier, Filter: imaging.Box}
+	dst, err := transformGIF(src, conf, func(src image.Image, conf imageConfig) (image.Image, error) {
+		return smartCrop(src, conf.Width, conf.Height, conf.Anchor, conf.Filter)
+	})
+	assert.NoError(err)
+	assert.Len(dst.Image, 2)
+
+	for _, frame := range dst.Image {
+		assert.Equal(image.Rect(0, 0, 40, 40), frame.Bounds())
+	}
+
+	// The crop is found in the first frame and used for the second, which
+	// then only gets the gray left half.
+	assert.Equal(uint8(red), dst.Image[0].ColorIndexAt(10, 20))
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 40; x++ {
+			assert.Equal(uint8(gray), dst.Image[1].ColorIndexAt(x, y))
+		}
+	}
+}
+
 func TestImageResizeInSubPath(t *testing.T) {
 
 	assert := require.New(t)
@@ -352,3 +485,93 @@ func BenchmarkResizeParallel(b *testing.B) {
 		}
 	})
 }
+
+func TestImageColors(t *testing.T) {
+
+	assert := require.New(t)
+
+	spec := newTestResourceSpec(assert)
+
+	red := fetchImageForSpec(spec, assert, "red.png")
+	colors, err := red.Colors()
+	assert.NoError(err)
+	assert.Equal([]string{"#ff0000"}, colors)
+
+	sunset := fetchImageForSpec(spec, assert, "sunset.jpg")
+	colors, err = sunset.Colors()
+	assert.NoError(err)
+	assert.Len(colors, dominantColorsCount)
+	for _, c := range colors {
+		assert.Regexp("^#[0-9a-f]{6}$", c)
+	}
+
+	// Cached.
+	colorsAgain, err := sunset.Colors()
+	assert.NoError(err)
+	assert.Equal(colors, colorsAgain)
+
+	colors3, err := sunset.Colors(3)
+	assert.NoError(err)
+	assert.Equal(colors[:3], colors3)
+
+	colors8, err := sunset.Colors(8)
+	assert.NoError(err)
+	assert.Len(colors8, 8)
+	assert.Equal(colors, colors8[:dominantColorsCount])
+
+	_, err = sunset.Colors(0)
+	assert.Error(err)
+
+}
+
+func TestImageLQIP(t *testing.T) {
+
+	assert := require.New(t)
+
+	spec := newTestResourceSpec(assert)
+
+	sunset := fetchImageForSpec(spec, assert, "sunset.jpg")
+	lqip, err := sunset.LQIP()
+	assert.NoError(err)
+	assert.True(strings.HasPrefix(string(lqip), "data:image/jpeg;base64,"), string(lqip))
+	// The source is about 90 KB.
+	assert.True(len(lqip) < 2000, "too big: %d", len(lqip))
+	assert.True(int64(len(lqip)) < sunset.osFileInfo.Size()/20)
+
+	lqipAgain, err := sunset.LQIP()
+	assert.NoError(err)
+	assert.Equal(lqip, lqipAgain)
+
+	png := fetchImageForSpec(spec, assert, "gohugoio.png")
+	lqip, err = png.LQIP()
+	assert.NoError(err)
+	assert.True(strings.HasPrefix(string(lqip), "data:image/png;base64,"), string(lqip))
+
+}
+
+func TestImageSrcset(t *testing.T) {
+
+	assert := require.New(t)
+
+	image := fetchSunset(assert)
+	assert.Equal(900, image.Width())
+
+	srcset, err := image.Srcset(300, 600, 900, 1200, 2000)
+	assert.NoError(err)
+
+	candidates := strings.Split(srcset, ", ")
+	assert.Len(candidates, 3)
+	assert.True(strings.HasSuffix(candidates[0], "_300x0_resize_q68_linear.jpg 300w"), candidates[0])
+	assert.True(strings.HasSuffix(candidates[1], "_600x0_resize_q68_linear.jpg 600w"), candidates[1])
+	assert.Equal("/a/sunset.jpg 900w", candidates[2])
+	assert.NotContains(srcset, "1200w")
+	assert.NotContains(srcset, "2000w")
+
+	srcset, err = image.Srcset(1000)
+	assert.NoError(err)
+	assert.Equal("/a/sunset.jpg 900w", srcset)
+
+	_, err = image.Srcset(0)
+	assert.Error(err)
+
+}
@@ -60,21 +60,28 @@ func smartCrop(img image.Image, width, height int, anchor imaging.Anchor, filter
 		return imaging.Clone(img), nil
 	}
 
-	smart := newSmartCropAnalyzer(filter)
-
-	rect, err := smart.FindBestCrop(img, width, height)
-
+	rect, err := smartCropRect(img, width, height, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	b := img.Bounds().Intersect(rect)
+	return cropAndResize(img, rect, width, height, filter), nil
+
+}
 
-	cropped, err := imaging.Crop(img, b), nil
+// smartCropRect returns the best crop of img for the given width and height,
+// in img's coordinates.
+func smartCropRect(img image.Image, width, height int, filter imaging.ResampleFilter) (image.Rectangle, error) {
+	smart := newSmartCropAnalyzer(filter)
+
+	rect, err := smart.FindBestCrop(img, width, height)
 	if err != nil {
-		return nil, err
+		return image.Rectangle{}, err
 	}
 
-	return imaging.Resize(cropped, width, height, filter), nil
+	return img.Bounds().Intersect(rect), nil
+}
 
+func cropAndResize(img image.Image, rect image.Rectangle, width, height int, filter imaging.ResampleFilter) *image.NRGBA {
+	return imaging.Resize(imaging.Crop(img, rect), width, height, filter)
 }
@@ -369,8 +369,21 @@ func (ns *Namespace) Last(limit interface{}, seq interface{}) (interface{}, erro
 }
 
 // Querify encodes the given parameters in URL-encoded form ("bar=baz&foo=quux") sorted by key.
+// The parameters can be given as key/value pairs or as a single map.
 func (ns *Namespace) Querify(params ...interface{}) (string, error) {
 	qs := url.Values{}
+
+	if len(params) == 1 {
+		m := reflect.ValueOf(params[0])
+		if m.Kind() != reflect.Map || m.Type().Key().Kind() != reflect.String {
+			return "", errors.New("querify requires a map or key/value pairs")
+		}
+		for _, k := range m.MapKeys() {
+			qs.Add(k.String(), fmt.Sprintf("%v", m.MapIndex(k).Interface()))
+		}
+		return qs.Encode(), nil
+	}
+
 	vals, err := ns.Dictionary(params...)
 	if err != nil {
 		return "", errors.New("querify keys must be strings")
@@ -450,9 +450,15 @@ func TestQuerify(t *testing.T) {
 	}{
 		{[]interface{}{"a", "b"}, "a=b"},
 		{[]interface{}{"a", "b", "c", "d", "f", " &"}, `a=b&c=d&f=+%26`},
+		{[]interface{}{"a", 1, "b", "two"}, `a=1&b=two`},
+		{[]interface{}{"q", "ä/ö?#=+", "path", "/a b/"}, `path=%2Fa+b%2F&q=%C3%A4%2F%C3%B6%3F%23%3D%2B`},
+		{[]interface{}{"z", 1, "m", 2, "a", 3}, `a=3&m=2&z=1`},
+		{[]interface{}{map[string]interface{}{"b": "two", "a": 1}}, `a=1&b=two`},
+		{[]interface{}{map[string]string{"q": "a&b"}}, `q=a%26b`},
 		// errors
 		{[]interface{}{5, "b"}, false},
 		{[]interface{}{"a", "b", "c"}, false},
+		{[]interface{}{"a"}, false},
 	} {
 		errMsg := fmt.Sprintf("[%d] %v", i, test.params)
 
@@ -106,6 +106,9 @@ func init() {
 				{
 					`<a href="https://www.google.com?{{ (querify "q" "test" "page" 3) | safeURL }}">Search</a>`,
 					`<a href="https://www.google.com?page=3&amp;q=test">Search</a>`},
+				{
+					`{{ (querify (dict "q" "test" "page" 3)) | safeHTML }}`,
+					`page=3&q=test`},
 			},
 		)
 
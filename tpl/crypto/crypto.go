@@ -18,6 +18,7 @@ import (
 	"crypto/sha1"
 	"crypto/sha256"
 	"encoding/hex"
+	"hash/fnv"
 
 	"github.com/spf13/cast"
 )
@@ -30,6 +31,18 @@ func New() *Namespace {
 // Namespace provides template functions for the "crypto" namespace.
 type Namespace struct{}
 
+// FNV32a hashes the given input and returns its 32-bit FNV-1a hash.
+func (ns *Namespace) FNV32a(in interface{}) (string, error) {
+	conv, err := cast.ToStringE(in)
+	if err != nil {
+		return "", err
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(conv))
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // MD5 hashes the given input and returns its MD5 checksum.
 func (ns *Namespace) MD5(in interface{}) (string, error) {
 	conv, err := cast.ToStringE(in)
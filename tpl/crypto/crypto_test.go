@@ -21,6 +21,34 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestFNV32a(t *testing.T) {
+	t.Parallel()
+
+	ns := New()
+
+	for i, test := range []struct {
+		in     interface{}
+		expect interface{}
+	}{
+		{"Hello world, gophers!", "06a7c116"},
+		{"foobar", "bf9cf968"},
+		{"", "811c9dc5"},
+		{t, false},
+	} {
+		errMsg := fmt.Sprintf("[%d] %v", i, test.in)
+
+		result, err := ns.FNV32a(test.in)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			require.Error(t, err, errMsg)
+			continue
+		}
+
+		require.NoError(t, err, errMsg)
+		assert.Equal(t, test.expect, result, errMsg)
+	}
+}
+
 func TestMD5(t *testing.T) {
 	t.Parallel()
 
@@ -29,6 +29,13 @@ func init() {
 			Context: func(args ...interface{}) interface{} { return ctx },
 		}
 
+		ns.AddMethodMapping(ctx.FNV32a,
+			nil,
+			[][2]string{
+				{`{{ crypto.FNV32a "Hello world, gophers!" }}`, `06a7c116`},
+			},
+		)
+
 		ns.AddMethodMapping(ctx.MD5,
 			[]string{"md5"},
 			[][2]string{
@@ -51,6 +58,18 @@ func init() {
 			},
 		)
 
+		ns.AddMethodMapping(ctx.UUID,
+			[]string{"uuid"},
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.UUIDFrom,
+			nil,
+			[][2]string{
+				{`{{ crypto.UUIDFrom "https://gohugo.io/" }}`, `f72f1cd3-c967-5d3d-9280-2440ebd269eb`},
+			},
+		)
+
 		return ns
 
 	}
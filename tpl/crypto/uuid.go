@@ -0,0 +1,65 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"fmt"
+
+	"github.com/spf13/cast"
+)
+
+// uuidNamespace is the RFC 4122 namespace for URLs, used for name based
+// UUIDs.
+var uuidNamespace = []byte{
+	0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1,
+	0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8,
+}
+
+// UUID returns a new random (version 4) UUID.
+func (ns *Namespace) UUID() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", err
+	}
+
+	return formatUUID(u, 4), nil
+}
+
+// UUIDFrom returns a name based (version 5) UUID for the given input. The
+// same input always gives the same UUID, which is useful for reproducible
+// builds.
+func (ns *Namespace) UUIDFrom(in interface{}) (string, error) {
+	conv, err := cast.ToStringE(in)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha1.New()
+	h.Write(uuidNamespace)
+	h.Write([]byte(conv))
+
+	var u [16]byte
+	copy(u[:], h.Sum(nil))
+
+	return formatUUID(u, 5), nil
+}
+
+func formatUUID(u [16]byte, version byte) string {
+	u[6] = (u[6] & 0x0f) | version<<4
+	u[8] = (u[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:])
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var uuidRe = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-([45])[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestUUID(t *testing.T) {
+	t.Parallel()
+
+	ns := New()
+
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		u, err := ns.UUID()
+		require.NoError(t, err)
+		m := uuidRe.FindStringSubmatch(u)
+		require.NotNil(t, m, u)
+		assert.Equal(t, "4", m[1])
+		require.False(t, seen[u], u)
+		seen[u] = true
+	}
+}
+
+func TestUUIDFrom(t *testing.T) {
+	t.Parallel()
+
+	ns := New()
+
+	for i, test := range []struct {
+		in     interface{}
+		expect interface{}
+	}{
+		{"https://gohugo.io/", "f72f1cd3-c967-5d3d-9280-2440ebd269eb"},
+		{"my-id", "399d9413-bc8c-50a4-8ef4-3b46da41efb5"},
+		{t, false},
+	} {
+		errMsg := fmt.Sprintf("[%d] %v", i, test.in)
+
+		result, err := ns.UUIDFrom(test.in)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			require.Error(t, err, errMsg)
+			continue
+		}
+
+		require.NoError(t, err, errMsg)
+		assert.Equal(t, test.expect, result, errMsg)
+		assert.Regexp(t, uuidRe, result, errMsg)
+
+		again, err := ns.UUIDFrom(test.in)
+		require.NoError(t, err)
+		assert.Equal(t, result, again, errMsg)
+	}
+}
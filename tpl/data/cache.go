@@ -17,10 +17,15 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/gohugoio/hugo/config"
-	"github.com/gohugoio/hugo/helpers"
 	"github.com/spf13/afero"
 )
 
@@ -43,17 +48,111 @@ func getCache(id string, fs afero.Fs, cfg config.Provider, ignoreCache bool) ([]
 	defer cacheMu.RUnlock()
 
 	fID := getCacheFileID(cfg, id)
-	isExists, err := helpers.Exists(fID, fs)
+	fi, err := fs.Stat(fID)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
 		return nil, err
 	}
-	if !isExists {
-		return nil, nil
+
+	expired, err := isCacheExpired(fID, fi.ModTime(), fs, cfg)
+	if err != nil || expired {
+		return nil, err
 	}
 
 	return afero.ReadFile(fs, fID)
 }
 
+// isCacheExpired reports whether the cache entry is too old to be used. The
+// expiry time set by the remote server's caching headers takes precedence
+// over the remoteDataCacheTTL setting. A zero TTL means that the entry never
+// expires.
+func isCacheExpired(fID string, modTime time.Time, fs afero.Fs, cfg config.Provider) (bool, error) {
+	b, err := afero.ReadFile(fs, fID+cacheExpiresSuffix)
+	if err == nil {
+		expires, err := time.Parse(time.RFC3339, string(b))
+		if err != nil {
+			return false, err
+		}
+		return time.Now().After(expires), nil
+	}
+
+	ttlStr := cfg.GetString("remoteDataCacheTTL")
+	if ttlStr == "" {
+		return false, nil
+	}
+
+	ttl, err := time.ParseDuration(ttlStr)
+	if err != nil {
+		return false, fmt.Errorf("invalid remoteDataCacheTTL %q: %s", ttlStr, err)
+	}
+	if ttl <= 0 {
+		return false, nil
+	}
+
+	return time.Now().After(modTime.Add(ttl)), nil
+}
+
+const cacheExpiresSuffix = ".expires"
+
+// writeCacheExpiry stores the time the cache entry for the given ID expires.
+// A zero time removes any expiry set before.
+func writeCacheExpiry(id string, expires time.Time, fs afero.Fs, cfg config.Provider, ignoreCache bool) error {
+	if ignoreCache {
+		return nil
+	}
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	fID := getCacheFileID(cfg, id)
+
+	if expires.IsZero() {
+		err := fs.Remove(fID + cacheExpiresSuffix)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	return afero.WriteFile(fs, fID+cacheExpiresSuffix, []byte(expires.Format(time.RFC3339)), 0644)
+}
+
+// cacheExpiryFromHeader returns when a response with the given headers should
+// expire from the cache, if set, and whether the response can be cached at all.
+func cacheExpiryFromHeader(h http.Header, now time.Time) (expires time.Time, cacheable bool) {
+	if cc := h.Get("Cache-Control"); cc != "" {
+		for _, directive := range strings.Split(cc, ",") {
+			directive = strings.ToLower(strings.TrimSpace(directive))
+			switch {
+			case directive == "no-store", directive == "no-cache":
+				return time.Time{}, false
+			case strings.HasPrefix(directive, "max-age="):
+				seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+				if err != nil {
+					continue
+				}
+				if seconds <= 0 {
+					return time.Time{}, false
+				}
+				return now.Add(time.Duration(seconds) * time.Second), true
+			}
+		}
+	}
+
+	if e := h.Get("Expires"); e != "" {
+		t, err := http.ParseTime(e)
+		if err != nil || !t.After(now) {
+			// Invalid values, e.g. "0", mean that it has already expired.
+			return time.Time{}, false
+		}
+		return t, true
+	}
+
+	return time.Time{}, true
+}
+
 // writeCache writes bytes associated with an ID into the file cache.
 func writeCache(id string, c []byte, fs afero.Fs, cfg config.Provider, ignoreCache bool) error {
 	if ignoreCache {
@@ -81,5 +180,7 @@ func writeCache(id string, c []byte, fs afero.Fs, cfg config.Provider, ignoreCac
 }
 
 func deleteCache(id string, fs afero.Fs, cfg config.Provider) error {
-	return fs.Remove(getCacheFileID(cfg, id))
+	fID := getCacheFileID(cfg, id)
+	fs.Remove(fID + cacheExpiresSuffix)
+	return fs.Remove(fID)
 }
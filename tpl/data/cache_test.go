@@ -15,7 +15,9 @@ package data
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/spf13/afero"
 	"github.com/spf13/viper"
@@ -61,3 +63,34 @@ func TestCache(t *testing.T) {
 		}
 	}
 }
+
+func TestCacheExpiryFromHeader(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	for i, test := range []struct {
+		key, value      string
+		expectExpires   time.Time
+		expectCacheable bool
+	}{
+		{"", "", time.Time{}, true},
+		{"Cache-Control", "public, max-age=60", now.Add(time.Minute), true},
+		{"Cache-Control", "private, no-cache", time.Time{}, false},
+		{"Cache-Control", "no-store", time.Time{}, false},
+		{"Cache-Control", "max-age=0", time.Time{}, false},
+		{"Expires", "Sun, 01 Jul 2018 13:00:00 GMT", now.Add(time.Hour), true},
+		{"Expires", "Sun, 01 Jul 2018 11:00:00 GMT", time.Time{}, false},
+		{"Expires", "0", time.Time{}, false},
+	} {
+		msg := fmt.Sprintf("Test #%d: %s: %s", i, test.key, test.value)
+		h := make(http.Header)
+		if test.key != "" {
+			h.Set(test.key, test.value)
+		}
+
+		expires, cacheable := cacheExpiryFromHeader(h, now)
+		assert.Equal(t, test.expectCacheable, cacheable, msg)
+		assert.True(t, test.expectExpires.Equal(expires), msg)
+	}
+}
@@ -18,11 +18,13 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/gohugoio/hugo/deps"
+	"github.com/spf13/cast"
 	jww "github.com/spf13/jwalterweatherman"
 )
 
@@ -91,15 +93,23 @@ func (ns *Namespace) GetCSV(sep string, urlParts ...string) (d [][]string, err e
 // GetJSON expects one or n-parts of a URL to a resource which can either be a local or a remote one.
 // If you provide multiple parts they will be joined together to the final URL.
 // GetJSON returns nil or parsed JSON to use in a short code.
-func (ns *Namespace) GetJSON(urlParts ...string) (v interface{}, err error) {
-	url := strings.Join(urlParts, "")
+func (ns *Namespace) GetJSON(urlParts ...string) (interface{}, error) {
+	v, err := ns.getJSON(strings.Join(urlParts, ""))
+	if err != nil {
+		jww.ERROR.Print(err)
+		return nil, err
+	}
+	return v, nil
+}
 
+// getJSON fetches and parses the JSON resource at url. It leaves it to the
+// caller to decide how to log any error.
+func (ns *Namespace) getJSON(url string) (v interface{}, err error) {
 	for i := 0; i <= resRetries; i++ {
 		var req *http.Request
 		req, err = http.NewRequest("GET", url, nil)
 		if err != nil {
-			jww.ERROR.Printf("Failed to create request for getJSON: %s", err)
-			return nil, err
+			return nil, fmt.Errorf("Failed to create request for getJSON: %s", err)
 		}
 
 		req.Header.Add("Accept", "application/json")
@@ -107,15 +117,16 @@ func (ns *Namespace) GetJSON(urlParts ...string) (v interface{}, err error) {
 		var c []byte
 		c, err = ns.getResource(req)
 		if err != nil {
-			jww.ERROR.Printf("Failed to get json resource %s with error message %s", url, err)
-			return nil, err
+			return nil, fmt.Errorf("Failed to get json resource %s with error message %s", url, err)
 		}
 
 		err = json.Unmarshal(c, &v)
 		if err != nil {
-			jww.ERROR.Printf("Cannot read json from resource %s with error message %s", url, err)
-			jww.ERROR.Printf("Retry #%d for %s and sleeping for %s", i, url, resSleep)
-			time.Sleep(resSleep)
+			err = fmt.Errorf("Cannot read json from resource %s with error message %s", url, err)
+			if i < resRetries {
+				jww.WARN.Printf("Retry #%d for %s and sleeping for %s", i+1, url, resSleep)
+				time.Sleep(resSleep)
+			}
 			deleteCache(url, ns.deps.Fs.Source, ns.deps.Cfg)
 			continue
 		}
@@ -124,6 +135,82 @@ func (ns *Namespace) GetJSON(urlParts ...string) (v interface{}, err error) {
 	return
 }
 
+// GetGraphQL sends the GraphQL query, with the optional variables, to the
+// given endpoint and returns the data field of the response. The response is
+// cached like GetJSON's. Any errors in the response are returned as an error.
+func (ns *Namespace) GetGraphQL(endpoint, query string, variables ...interface{}) (interface{}, error) {
+	payload := map[string]interface{}{"query": query}
+
+	if len(variables) > 0 && variables[0] != nil {
+		vars, err := cast.ToStringMapE(variables[0])
+		if err != nil {
+			return nil, fmt.Errorf("GraphQL variables must be a map: %s", err)
+		}
+		payload["variables"] = vars
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		jww.ERROR.Printf("Failed to create request for getGraphQL: %s", err)
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Accept", "application/json")
+
+	c, err := getRemote(req, ns.deps.Fs.Source, ns.deps.Cfg, ns.client)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get GraphQL resource %s: %s", endpoint, err)
+	}
+
+	var res struct {
+		Data   interface{}
+		Errors []struct {
+			Message string
+		}
+	}
+
+	// Do not keep failed queries in the cache.
+	clearCache := func() {
+		if id, err := requestCacheID(req); err == nil {
+			deleteCache(id, ns.deps.Fs.Source, ns.deps.Cfg)
+		}
+	}
+
+	if err := json.Unmarshal(c, &res); err != nil {
+		clearCache()
+		return nil, fmt.Errorf("Cannot read GraphQL response from %s: %s", endpoint, err)
+	}
+
+	if len(res.Errors) > 0 {
+		clearCache()
+		messages := make([]string, len(res.Errors))
+		for i, e := range res.Errors {
+			messages[i] = e.Message
+		}
+		return nil, fmt.Errorf("GraphQL query to %s failed: %s", endpoint, strings.Join(messages, "; "))
+	}
+
+	return res.Data, nil
+}
+
+// TryGetJSON works like GetJSON, but logs a warning and returns nil instead
+// of failing if the resource cannot be fetched or parsed. This is used by
+// the internal shortcodes to render a fallback if a remote service is down.
+func (ns *Namespace) TryGetJSON(urlParts ...string) interface{} {
+	v, err := ns.getJSON(strings.Join(urlParts, ""))
+	if err != nil {
+		jww.WARN.Print(err)
+		return nil
+	}
+	return v
+}
+
 // parseCSV parses bytes of CSV data into a slice slice string or an error
 func parseCSV(c []byte, sep string) ([][]string, error) {
 	if len(sep) != 1 {
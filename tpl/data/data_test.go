@@ -14,13 +14,16 @@
 package data
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	jww "github.com/spf13/jwalterweatherman"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -248,3 +251,96 @@ func haveHeader(m http.Header, key, needle string) bool {
 	}
 	return false
 }
+
+// Not parallel, as it stubs resSleep and counts the global ERROR logs.
+func TestTryGetJSON(t *testing.T) {
+	defer func(d time.Duration) { resSleep = d }(resSleep)
+	resSleep = time.Millisecond
+
+	ns := newTestNs()
+
+	srv, client := getTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Add("Content-type", "application/json")
+		w.Write([]byte(`{"html":"<blockquote>Tweet</blockquote>"}`))
+	})
+	defer srv.Close()
+	ns.client = client
+
+	got := ns.TryGetJSON("http://fake.test/oembed")
+	assert.Equal(t, map[string]interface{}{"html": "<blockquote>Tweet</blockquote>"}, got)
+
+	errorCount := jww.LogCountForLevel(jww.LevelError)
+	assert.Nil(t, ns.TryGetJSON("http://fake.test/down"))
+	assert.Nil(t, ns.TryGetJSON("fail/no-file"))
+	assert.Equal(t, errorCount, jww.LogCountForLevel(jww.LevelError))
+}
+
+func TestGetGraphQL(t *testing.T) {
+	t.Parallel()
+
+	ns := newTestNs()
+
+	hits := 0
+	srv, client := getTestServer(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.Method != "POST" || !haveHeader(r.Header, "Content-Type", "application/json") {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		var req struct {
+			Query     string
+			Variables map[string]interface{}
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Add("Content-type", "application/json")
+
+		if strings.Contains(req.Query, "broken") {
+			w.Write([]byte(`{"data":null,"errors":[{"message":"Cannot query field \"broken\""}]}`))
+			return
+		}
+
+		fmt.Fprintf(w, `{"data":{"repository":{"name":%q,"owner":%q}}}`, req.Variables["name"], req.Variables["owner"])
+	})
+	defer srv.Close()
+	ns.client = client
+
+	query := `query($owner: String!, $name: String!) { repository(owner: $owner, name: $name) { name } }`
+
+	got, err := ns.GetGraphQL("http://example.org/graphql", query, map[string]interface{}{"owner": "gohugoio", "name": "hugo"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"repository": map[string]interface{}{"name": "hugo", "owner": "gohugoio"}}, got)
+	assert.Equal(t, 1, hits)
+
+	// Cached.
+	got, err = ns.GetGraphQL("http://example.org/graphql", query, map[string]interface{}{"owner": "gohugoio", "name": "hugo"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"repository": map[string]interface{}{"name": "hugo", "owner": "gohugoio"}}, got)
+	assert.Equal(t, 1, hits)
+
+	// Other variables, other result.
+	got, err = ns.GetGraphQL("http://example.org/graphql", query, map[string]interface{}{"owner": "spf13", "name": "cobra"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"repository": map[string]interface{}{"name": "cobra", "owner": "spf13"}}, got)
+	assert.Equal(t, 2, hits)
+
+	_, err = ns.GetGraphQL("http://example.org/graphql", `{ broken }`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `Cannot query field "broken"`)
+
+	// Errors are not cached.
+	_, err = ns.GetGraphQL("http://example.org/graphql", `{ broken }`)
+	require.Error(t, err)
+	assert.Equal(t, 4, hits)
+
+	_, err = ns.GetGraphQL("http://example.org/graphql", query, "owner=gohugoio")
+	require.Error(t, err)
+}
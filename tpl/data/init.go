@@ -38,6 +38,16 @@ func init() {
 			[]string{"getJSON"},
 			[][2]string{},
 		)
+
+		ns.AddMethodMapping(ctx.GetGraphQL,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.TryGetJSON,
+			nil,
+			[][2]string{},
+		)
 		return ns
 	}
 
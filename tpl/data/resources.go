@@ -66,7 +66,12 @@ func (l *remoteLock) URLUnlock(url string) {
 func getRemote(req *http.Request, fs afero.Fs, cfg config.Provider, hc *http.Client) ([]byte, error) {
 	url := req.URL.String()
 
-	c, err := getCache(url, fs, cfg, cfg.GetBool("ignoreCache"))
+	id, err := requestCacheID(req)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := getCache(id, fs, cfg, cfg.GetBool("ignoreCache"))
 	if err != nil {
 		return nil, err
 	}
@@ -75,11 +80,11 @@ func getRemote(req *http.Request, fs afero.Fs, cfg config.Provider, hc *http.Cli
 	}
 
 	// avoid race condition with locks, block other goroutines if the current url is processing
-	remoteURLLock.URLLock(url)
-	defer func() { remoteURLLock.URLUnlock(url) }()
+	remoteURLLock.URLLock(id)
+	defer func() { remoteURLLock.URLUnlock(id) }()
 
 	// avoid multiple locks due to calling getCache twice
-	c, err = getCache(url, fs, cfg, cfg.GetBool("ignoreCache"))
+	c, err = getCache(id, fs, cfg, cfg.GetBool("ignoreCache"))
 	if err != nil {
 		return nil, err
 	}
@@ -88,12 +93,37 @@ func getRemote(req *http.Request, fs afero.Fs, cfg config.Provider, hc *http.Cli
 	}
 
 	jww.INFO.Printf("Downloading: %s ...", url)
-	res, err := hc.Do(req)
+
+	var res *http.Response
+	for i := 0; i <= resRetries; i++ {
+		if i > 0 && req.GetBody != nil {
+			// The body was consumed by the previous attempt.
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		res, err = hc.Do(req)
+		if err == nil && res.StatusCode < 500 {
+			break
+		}
+
+		// Network errors and server errors may be transient, so try again.
+		if err == nil {
+			res.Body.Close()
+			err = fmt.Errorf("Failed to retrieve remote file: %s", http.StatusText(res.StatusCode))
+		}
+		if i < resRetries {
+			jww.WARN.Printf("Retry #%d for %s and sleeping for %s: %s", i+1, url, resSleep, err)
+			time.Sleep(resSleep)
+		}
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
 	if res.StatusCode < 200 || res.StatusCode > 299 {
+		res.Body.Close()
 		return nil, fmt.Errorf("Failed to retrieve remote file: %s", http.StatusText(res.StatusCode))
 	}
 
@@ -103,15 +133,49 @@ func getRemote(req *http.Request, fs afero.Fs, cfg config.Provider, hc *http.Cli
 		return nil, err
 	}
 
-	err = writeCache(url, c, fs, cfg, cfg.GetBool("ignoreCache"))
+	expires, cacheable := cacheExpiryFromHeader(res.Header, time.Now())
+	if !cacheable {
+		return c, nil
+	}
+
+	ignoreCache := cfg.GetBool("ignoreCache")
+
+	err = writeCache(id, c, fs, cfg, ignoreCache)
 	if err != nil {
 		return nil, err
 	}
 
-	jww.INFO.Printf("... and cached to: %s", getCacheFileID(cfg, url))
+	err = writeCacheExpiry(id, expires, fs, cfg, ignoreCache)
+	if err != nil {
+		return nil, err
+	}
+
+	jww.INFO.Printf("... and cached to: %s", getCacheFileID(cfg, id))
 	return c, nil
 }
 
+// requestCacheID returns the ID used to cache the response to req. This is
+// the URL, plus the body for requests that have one, e.g. GraphQL queries.
+func requestCacheID(req *http.Request) (string, error) {
+	id := req.URL.String()
+	if req.GetBody == nil {
+		return id, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+
+	return req.Method + " " + id + " " + string(b), nil
+}
+
 // getLocal loads the content of a local file
 func getLocal(url string, fs afero.Fs, cfg config.Provider) ([]byte, error) {
 	filename := filepath.Join(cfg.GetString("workingDir"), url)
@@ -183,3 +183,94 @@ func newTestNs() *Namespace {
 	v.Set("contentDir", "content")
 	return New(newDeps(v))
 }
+
+func TestScpGetRemoteCaching(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		name       string
+		header     [2]string
+		ttl        string
+		expectHits int
+	}{
+		{"No headers", [2]string{}, "", 1},
+		{"Max age", [2]string{"Cache-Control", "public, max-age=3600"}, "", 1},
+		{"No store", [2]string{"Cache-Control", "no-store"}, "", 2},
+		{"Max age 0", [2]string{"Cache-Control", "max-age=0"}, "", 2},
+		{"Expired", [2]string{"Expires", "Thu, 01 Dec 1994 16:00:00 GMT"}, "", 2},
+		{"TTL", [2]string{}, "1h", 1},
+		{"TTL expired", [2]string{}, "1ns", 2},
+		{"Max age wins over TTL", [2]string{"Cache-Control", "max-age=3600"}, "1ns", 1},
+	} {
+		msg := test.name
+		fs := new(afero.MemMapFs)
+		hits := 0
+
+		srv, cl := getTestServer(func(w http.ResponseWriter, r *http.Request) {
+			hits++
+			if test.header[0] != "" {
+				w.Header().Set(test.header[0], test.header[1])
+			}
+			w.Write([]byte(`{"a":1}`))
+		})
+
+		cfg := viper.New()
+		cfg.Set("remoteDataCacheTTL", test.ttl)
+
+		for i := 0; i < 2; i++ {
+			req, err := http.NewRequest("GET", "http://example.org/data.json", nil)
+			require.NoError(t, err, msg)
+			c, err := getRemote(req, fs, cfg, cl)
+			require.NoError(t, err, msg)
+			assert.Equal(t, `{"a":1}`, string(c), msg)
+		}
+
+		assert.Equal(t, test.expectHits, hits, msg)
+
+		srv.Close()
+	}
+}
+
+func TestScpGetRemoteErrors(t *testing.T) {
+	t.Parallel()
+
+	fs := new(afero.MemMapFs)
+	cfg := viper.New()
+	hits := make(map[string]int)
+	var mu sync.Mutex
+
+	srv, cl := getTestServer(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.URL.Path]++
+		count := hits[r.URL.Path]
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/notfound":
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		case "/flaky":
+			// Fails the first time.
+			if count == 1 {
+				http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte("ok"))
+		}
+	})
+	defer srv.Close()
+
+	req, err := http.NewRequest("GET", "http://example.org/notfound", nil)
+	require.NoError(t, err)
+	_, err = getRemote(req, fs, cfg, cl)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Not Found")
+	// Client errors are not retried.
+	assert.Equal(t, 1, hits["/notfound"])
+
+	req, err = http.NewRequest("GET", "http://example.org/flaky", nil)
+	require.NoError(t, err)
+	c, err := getRemote(req, fs, cfg, cl)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(c))
+	assert.Equal(t, 2, hits["/flaky"])
+}
@@ -29,7 +29,8 @@ func New() *Namespace {
 // Namespace provides template functions for the "encoding" namespace.
 type Namespace struct{}
 
-// Base64Decode returns the base64 decoding of the given content.
+// Base64Decode returns the base64 decoding of the given content, a string
+// or a byte slice.
 func (ns *Namespace) Base64Decode(content interface{}) (string, error) {
 	conv, err := cast.ToStringE(content)
 	if err != nil {
@@ -37,10 +38,15 @@ func (ns *Namespace) Base64Decode(content interface{}) (string, error) {
 	}
 
 	dec, err := base64.StdEncoding.DecodeString(conv)
-	return string(dec), err
+	if err != nil {
+		return "", err
+	}
+
+	return string(dec), nil
 }
 
-// Base64Encode returns the base64 encoding of the given content.
+// Base64Encode returns the base64 encoding of the given content, a string
+// or a byte slice.
 func (ns *Namespace) Base64Encode(content interface{}) (string, error) {
 	conv, err := cast.ToStringE(content)
 	if err != nil {
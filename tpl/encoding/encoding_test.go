@@ -19,6 +19,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/spf13/cast"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -35,8 +36,12 @@ func TestBase64Decode(t *testing.T) {
 		expect interface{}
 	}{
 		{"YWJjMTIzIT8kKiYoKSctPUB+", "abc123!?$*&()'-=@~"},
+		{[]byte("SGVsbG8gd29ybGQ="), "Hello world"},
+		{template.HTML("SGVsbG8gd29ybGQ="), "Hello world"},
 		// errors
 		{t, false},
+		{"SGVsbG8gd29ybGQ", false},
+		{"not base64!", false},
 	} {
 		errMsg := fmt.Sprintf("[%d] %v", i, test.v)
 
@@ -62,6 +67,8 @@ func TestBase64Encode(t *testing.T) {
 		expect interface{}
 	}{
 		{"YWJjMTIzIT8kKiYoKSctPUB+", "WVdKak1USXpJVDhrS2lZb0tTY3RQVUIr"},
+		{[]byte("Hello world"), "SGVsbG8gd29ybGQ="},
+		{42, "NDI="},
 		// errors
 		{t, false},
 	} {
@@ -79,6 +86,20 @@ func TestBase64Encode(t *testing.T) {
 	}
 }
 
+func TestBase64RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ns := New()
+
+	for _, v := range []interface{}{"", "Hello world", "Ærlig 🎉\n", []byte{0, 1, 2, 255}} {
+		enc, err := ns.Base64Encode(v)
+		require.NoError(t, err)
+		dec, err := ns.Base64Decode(enc)
+		require.NoError(t, err)
+		assert.Equal(t, cast.ToString(v), dec)
+	}
+}
+
 func TestJsonify(t *testing.T) {
 	t.Parallel()
 
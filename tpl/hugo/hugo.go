@@ -0,0 +1,49 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hugo provides template functions for accessing information about
+// the running Hugo build.
+package hugo
+
+import (
+	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/helpers"
+)
+
+// New returns a new instance of the hugo-namespaced template functions.
+func New(deps *deps.Deps) *Namespace {
+	return &Namespace{
+		deps: deps,
+	}
+}
+
+// Namespace provides template functions for the "hugo" namespace.
+type Namespace struct {
+	deps *deps.Deps
+}
+
+// Environment returns the current build environment, e.g. "production" or
+// "development".
+func (ns *Namespace) Environment() string {
+	return helpers.BuildEnvironment(ns.deps.Cfg, ns.deps.Running)
+}
+
+// IsProduction returns whether the current build environment is "production".
+func (ns *Namespace) IsProduction() bool {
+	return ns.Environment() == helpers.EnvironmentProduction
+}
+
+// Version returns the current Hugo version.
+func (ns *Namespace) Version() helpers.HugoVersionString {
+	return helpers.CurrentHugoVersion.Version()
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvironment(t *testing.T) {
+	t.Parallel()
+
+	for i, test := range []struct {
+		environment  string
+		running      bool
+		expect       string
+		isProduction bool
+	}{
+		{"", false, "production", true},
+		{"", true, "development", false},
+		{"production", true, "production", true},
+		{"staging", false, "staging", false},
+	} {
+		errMsg := fmt.Sprintf("[%d] %v", i, test)
+
+		v := viper.New()
+		if test.environment != "" {
+			v.Set("environment", test.environment)
+		}
+
+		ns := New(&deps.Deps{Cfg: v, Running: test.running})
+
+		require.Equal(t, test.expect, ns.Environment(), errMsg)
+		require.Equal(t, test.isProduction, ns.IsProduction(), errMsg)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	t.Parallel()
+
+	ns := New(&deps.Deps{Cfg: viper.New()})
+
+	require.Equal(t, helpers.CurrentHugoVersion.Version(), ns.Version())
+}
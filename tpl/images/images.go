@@ -15,7 +15,10 @@ package images
 
 import (
 	"errors"
+	"fmt"
 	"image"
+	"io/ioutil"
+	"strings"
 	"sync"
 
 	// Importing image codecs for image.DecodeConfig
@@ -27,6 +30,7 @@ import (
 	_ "golang.org/x/image/webp"
 
 	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/resource"
 	"github.com/spf13/cast"
 )
 
@@ -84,3 +88,81 @@ func (ns *Namespace) Config(path interface{}) (image.Config, error) {
 
 	return config, nil
 }
+
+// Text creates a filter that draws the given text onto an image, e.g.
+// {{ $img.Filter (images.Text "Hello" (dict "x" 10 "y" 10 "size" 24 "color" "#fff")) }}.
+// The options are x, y, size, color and font, where font is a TrueType or
+// OpenType font resource.
+func (ns *Namespace) Text(text interface{}, options ...interface{}) (resource.ImageFilter, error) {
+	s, err := cast.ToStringE(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts resource.TextOptions
+
+	if len(options) > 0 {
+		m, err := cast.ToStringMapE(options[0])
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range m {
+			switch strings.ToLower(k) {
+			case "x":
+				opts.X, err = cast.ToIntE(v)
+			case "y":
+				opts.Y, err = cast.ToIntE(v)
+			case "size":
+				opts.Size, err = cast.ToFloat64E(v)
+			case "color":
+				opts.Color, err = cast.ToStringE(v)
+			case "font":
+				opts.Font, err = readResource(v)
+			default:
+				err = fmt.Errorf("unknown text option %q", k)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return resource.NewTextFilter(s, opts)
+}
+
+// Overlay creates a filter that draws the image src on top of another image
+// with its top left corner at x and y, e.g. {{ $img.Filter (images.Overlay $logo 10 10) }}.
+func (ns *Namespace) Overlay(src interface{}, x, y interface{}) (resource.ImageFilter, error) {
+	img, ok := src.(*resource.Image)
+	if !ok {
+		return nil, fmt.Errorf("%T is not an image", src)
+	}
+
+	xv, err := cast.ToIntE(x)
+	if err != nil {
+		return nil, err
+	}
+
+	yv, err := cast.ToIntE(y)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource.NewOverlayFilter(img, xv, yv), nil
+}
+
+func readResource(v interface{}) ([]byte, error) {
+	r, ok := v.(resource.ReadSeekCloserResource)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a resource", v)
+	}
+
+	f, err := r.ReadSeekCloser()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ioutil.ReadAll(f)
+}
@@ -119,3 +119,32 @@ func blankImage(width, height int) []byte {
 	}
 	return buf.Bytes()
 }
+
+func TestNSText(t *testing.T) {
+	t.Parallel()
+
+	ns := New(&deps.Deps{})
+
+	filter, err := ns.Text("Hello", map[string]interface{}{"x": 10, "Y": "20", "size": 24, "color": "#fff"})
+	require.NoError(t, err)
+	assert.Equal(t, "text_Hello_10_20_24_#fff", filter.Key())
+
+	filter, err = ns.Text("Hello")
+	require.NoError(t, err)
+	assert.Equal(t, "text_Hello_0_0_20_", filter.Key())
+
+	_, err = ns.Text("Hello", map[string]interface{}{"angle": 90})
+	assert.Error(t, err)
+
+	_, err = ns.Text("Hello", map[string]interface{}{"font": "arial.ttf"})
+	assert.Error(t, err)
+}
+
+func TestNSOverlay(t *testing.T) {
+	t.Parallel()
+
+	ns := New(&deps.Deps{})
+
+	_, err := ns.Overlay("logo.png", 10, 10)
+	assert.Error(t, err)
+}
@@ -34,6 +34,16 @@ func init() {
 			[][2]string{},
 		)
 
+		ns.AddMethodMapping(ctx.Overlay,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Text,
+			nil,
+			[][2]string{},
+		)
+
 		return ns
 
 	}
@@ -55,22 +55,32 @@ func (ns *Namespace) Humanize(in interface{}) (string, error) {
 	return _inflect.Humanize(word), nil
 }
 
-// Pluralize returns the plural form of a single word.
+// Pluralize returns the plural form of a single word. Irregular words such
+// as "person" and "index" are handled, too.
 func (ns *Namespace) Pluralize(in interface{}) (string, error) {
 	word, err := cast.ToStringE(in)
 	if err != nil {
 		return "", err
 	}
 
+	if plural, found := inflectIrregular(word, irregulars); found {
+		return plural, nil
+	}
+
 	return _inflect.Pluralize(word), nil
 }
 
-// Singularize returns the singular form of a single word.
+// Singularize returns the singular form of a single word. Irregular words
+// such as "people" and "indices" are handled, too.
 func (ns *Namespace) Singularize(in interface{}) (string, error) {
 	word, err := cast.ToStringE(in)
 	if err != nil {
 		return "", err
 	}
 
+	if singular, found := inflectIrregular(word, irregularSingulars); found {
+		return singular, nil
+	}
+
 	return _inflect.Singularize(word), nil
 }
@@ -33,6 +33,19 @@ func TestInflect(t *testing.T) {
 		{ns.Singularize, "cats", "cat"},
 		{ns.Singularize, "", ""},
 		{ns.Singularize, t, false},
+		// Irregulars
+		{ns.Pluralize, "person", "people"},
+		{ns.Pluralize, "Person", "People"},
+		{ns.Pluralize, "INDEX", "INDICES"},
+		{ns.Pluralize, "tooth", "teeth"},
+		{ns.Pluralize, "criterion", "criteria"},
+		{ns.Pluralize, "cactus", "cacti"},
+		{ns.Pluralize, "sales person", "sales people"},
+		{ns.Singularize, "people", "person"},
+		{ns.Singularize, "indices", "index"},
+		{ns.Singularize, "Geese", "Goose"},
+		{ns.Singularize, "criteria", "criterion"},
+		{ns.Singularize, "cacti", "cactus"},
 	} {
 		errMsg := fmt.Sprintf("[%d] %v", i, test)
 
@@ -0,0 +1,74 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inflect
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// irregulars maps singular to plural for words the rule based inflector
+// gets wrong.
+var irregulars = map[string]string{
+	"appendix":   "appendices",
+	"cactus":     "cacti",
+	"child":      "children",
+	"criterion":  "criteria",
+	"die":        "dice",
+	"foot":       "feet",
+	"goose":      "geese",
+	"index":      "indices",
+	"leaf":       "leaves",
+	"louse":      "lice",
+	"man":        "men",
+	"matrix":     "matrices",
+	"mouse":      "mice",
+	"ox":         "oxen",
+	"person":     "people",
+	"phenomenon": "phenomena",
+	"tooth":      "teeth",
+	"vertex":     "vertices",
+	"woman":      "women",
+}
+
+var irregularSingulars = make(map[string]string)
+
+func init() {
+	for singular, plural := range irregulars {
+		irregularSingulars[plural] = singular
+	}
+}
+
+// inflectIrregular looks up the last word of s in table and, if found,
+// replaces it with its counterpart, keeping the case of its first letter.
+func inflectIrregular(s string, table map[string]string) (string, bool) {
+	start := strings.LastIndexFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) + 1
+	word := s[start:]
+
+	replacement, found := table[strings.ToLower(word)]
+	if !found {
+		return s, false
+	}
+
+	if strings.ToUpper(word) == word && len(word) > 1 {
+		replacement = strings.ToUpper(replacement)
+	} else if r, _ := utf8.DecodeRuneInString(word); unicode.IsUpper(r) {
+		replacement = strings.ToUpper(replacement[:1]) + replacement[1:]
+	}
+
+	return s[:start] + replacement, true
+}
@@ -112,6 +112,27 @@ func (ns *Namespace) ReadDir(i interface{}) ([]_os.FileInfo, error) {
 	return list, nil
 }
 
+// Stat returns the os.FileInfo describing the file or directory with the given
+// path relative to the configured WorkingDir. Unlike ReadFile, it does not look
+// in the content dirs, but it sees the same files as ReadDir.
+func (ns *Namespace) Stat(i interface{}) (_os.FileInfo, error) {
+	path, err := cast.ToStringE(i)
+	if err != nil {
+		return nil, err
+	}
+
+	if path == "" {
+		return nil, errors.New("os.Stat needs a path to a file")
+	}
+
+	r, err := ns.deps.Fs.WorkingDir.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
 // FileExists checks whether a file exists under the given path.
 func (ns *Namespace) FileExists(i interface{}) (bool, error) {
 	path, err := cast.ToStringE(i)
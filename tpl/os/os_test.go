@@ -75,6 +75,7 @@ func TestFileExists(t *testing.T) {
 	ns := New(&deps.Deps{Fs: hugofs.NewMem(v)})
 
 	afero.WriteFile(ns.deps.Fs.Source, filepath.Join(workingDir, "/f/f1.txt"), []byte("f1-content"), 0755)
+	afero.WriteFile(ns.deps.Fs.Source, filepath.Join(workingDir, "static", "foo.css"), []byte("body {}"), 0755)
 	afero.WriteFile(ns.deps.Fs.Source, filepath.Join("/home", "f2.txt"), []byte("f2-content"), 0755)
 
 	for i, test := range []struct {
@@ -83,7 +84,11 @@ func TestFileExists(t *testing.T) {
 	}{
 		{filepath.FromSlash("/f/f1.txt"), true},
 		{filepath.FromSlash("f/f1.txt"), true},
+		{filepath.FromSlash("static/foo.css"), true},
+		{filepath.FromSlash("static/bar.css"), false},
 		{filepath.FromSlash("../f2.txt"), false},
+		{filepath.FromSlash("static/../../f2.txt"), false},
+		{filepath.FromSlash("/../f2.txt"), false},
 		{"b", false},
 		{"", nil},
 	} {
@@ -99,3 +104,87 @@ func TestFileExists(t *testing.T) {
 		assert.Equal(t, test.expect, result, errMsg)
 	}
 }
+
+func TestReadDir(t *testing.T) {
+	t.Parallel()
+
+	workingDir := "/home/hugo"
+
+	v := viper.New()
+	v.Set("workingDir", workingDir)
+
+	ns := New(&deps.Deps{Fs: hugofs.NewMem(v)})
+
+	afero.WriteFile(ns.deps.Fs.Source, filepath.Join(workingDir, "static", "css", "a.css"), []byte("a"), 0755)
+	afero.WriteFile(ns.deps.Fs.Source, filepath.Join(workingDir, "static", "css", "b.css"), []byte("b"), 0755)
+	afero.WriteFile(ns.deps.Fs.Source, filepath.Join(workingDir, "static", "css", "vendor", "c.css"), []byte("c"), 0755)
+	afero.WriteFile(ns.deps.Fs.Source, filepath.Join("/home", "f2.txt"), []byte("f2-content"), 0755)
+
+	for i, test := range []struct {
+		path   string
+		expect interface{}
+	}{
+		{filepath.FromSlash("static/css"), []string{"a.css", "b.css", "vendor"}},
+		{filepath.FromSlash("/static/css/vendor"), []string{"c.css"}},
+		{filepath.FromSlash("../"), false},
+		{filepath.FromSlash("static/../../"), false},
+		{"b", false},
+	} {
+		errMsg := fmt.Sprintf("[%d] %v", i, test)
+
+		result, err := ns.ReadDir(test.path)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			require.Error(t, err, errMsg)
+			continue
+		}
+
+		require.NoError(t, err, errMsg)
+
+		var names []string
+		for _, fi := range result {
+			names = append(names, fi.Name())
+		}
+		assert.Equal(t, test.expect, names, errMsg)
+	}
+}
+
+func TestStat(t *testing.T) {
+	t.Parallel()
+
+	workingDir := "/home/hugo"
+
+	v := viper.New()
+	v.Set("workingDir", workingDir)
+
+	ns := New(&deps.Deps{Fs: hugofs.NewMem(v)})
+
+	afero.WriteFile(ns.deps.Fs.Source, filepath.Join(workingDir, "/f/f1.txt"), []byte("f1-content"), 0755)
+	afero.WriteFile(ns.deps.Fs.Source, filepath.Join("/home", "f2.txt"), []byte("f2-content"), 0755)
+
+	for i, test := range []struct {
+		filename string
+		expect   interface{}
+	}{
+		{filepath.FromSlash("/f/f1.txt"), int64(10)},
+		{filepath.FromSlash("f/f1.txt"), int64(10)},
+		{filepath.FromSlash("../f2.txt"), nil},
+		{"b", nil},
+		{"", nil},
+	} {
+		errMsg := fmt.Sprintf("[%d] %v", i, test)
+		result, err := ns.Stat(test.filename)
+
+		if test.expect == nil {
+			require.Error(t, err, errMsg)
+			continue
+		}
+
+		require.NoError(t, err, errMsg)
+		assert.Equal(t, test.expect, result.Size(), errMsg)
+	}
+
+	fi, err := ns.Stat("f")
+	require.NoError(t, err)
+	assert.True(t, fi.IsDir())
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tpl
+
+import (
+	"io"
+	"strings"
+
+	"github.com/gohugoio/hugo/helpers"
+)
+
+const (
+	renderHookLinkTemplate  = "_default/_markup/render-link.html"
+	renderHookImageTemplate = "_default/_markup/render-image.html"
+	renderHookCodeTemplate  = "_default/_markup/render-codeblock.html"
+)
+
+// NewRenderHooks creates the Markdown render hooks from the render-link.html,
+// render-image.html and render-codeblock.html templates in
+// layouts/_default/_markup, if any. Code blocks in a given language, e.g.
+// mermaid, can have their own render-codeblock-mermaid.html template. The
+// page, if set, is made available to the templates as .Page.
+func NewRenderHooks(t TemplateFinder, page interface{}) *helpers.RenderHooks {
+	if t == nil {
+		return nil
+	}
+
+	var hooks helpers.RenderHooks
+
+	if templ, found := lookupRenderHook(t, renderHookLinkTemplate); found {
+		hooks.Link = linkRenderHook{templ: templ, page: page}
+	}
+
+	if templ, found := lookupRenderHook(t, renderHookImageTemplate); found {
+		hooks.Image = linkRenderHook{templ: templ, page: page}
+	}
+
+	defaultCodeBlock, hasDefaultCodeBlock := lookupRenderHook(t, renderHookCodeTemplate)
+
+	hooks.CodeBlock = func(lang string) helpers.CodeBlockRenderer {
+		if lang != "" {
+			name := strings.TrimSuffix(renderHookCodeTemplate, ".html") + "-" + strings.ToLower(lang) + ".html"
+			if templ, found := lookupRenderHook(t, name); found {
+				return codeBlockRenderHook{templ: templ, page: page}
+			}
+		}
+		if hasDefaultCodeBlock {
+			return codeBlockRenderHook{templ: defaultCodeBlock, page: page}
+		}
+		return nil
+	}
+
+	return &hooks
+}
+
+func lookupRenderHook(t TemplateFinder, name string) (Template, bool) {
+	if templ, found := t.Lookup(name); found {
+		return templ, true
+	}
+	return t.Lookup("theme/" + name)
+}
+
+type linkRenderHook struct {
+	templ Template
+	page  interface{}
+}
+
+func (h linkRenderHook) RenderLink(w io.Writer, ctx helpers.LinkContext) error {
+	ctx.Page = h.page
+	return h.templ.Execute(w, ctx)
+}
+
+type codeBlockRenderHook struct {
+	templ Template
+	page  interface{}
+}
+
+func (h codeBlockRenderHook) RenderCodeBlock(w io.Writer, ctx helpers.CodeBlockContext) error {
+	ctx.Page = h.page
+	return h.templ.Execute(w, ctx)
+}
@@ -61,7 +61,7 @@ type Namespace struct {
 	templatesClient *templates.Client
 }
 
-// Get locates the filename given in Hugo's filesystems: static, assets and content (in that order)
+// Get locates the filename given in Hugo's filesystems: assets and content (in that order)
 // and creates a Resource object that can be used for further transformations.
 func (ns *Namespace) Get(filename interface{}) (resource.Resource, error) {
 	filenamestr, err := cast.ToStringE(filename)
@@ -71,9 +71,9 @@ func (ns *Namespace) Get(filename interface{}) (resource.Resource, error) {
 
 	filenamestr = filepath.Clean(filenamestr)
 
-	// Resource Get'ing is currently limited to /assets to make it simpler
+	// Resource Get'ing is currently limited to /assets and /content to make it simpler
 	// to control the behaviour of publishing and partial rebuilding.
-	return ns.createClient.Get(ns.deps.BaseFs.Assets.Fs, filenamestr)
+	return ns.createClient.Get(ns.deps.BaseFs.AssetsContentFs(), filenamestr)
 
 }
 
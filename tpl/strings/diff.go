@@ -0,0 +1,138 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"fmt"
+	_strings "strings"
+
+	"github.com/spf13/cast"
+)
+
+// DiffHunk is a run of consecutive lines that differ between two strings.
+type DiffHunk struct {
+	// The 1-based line numbers where the hunk starts in the old and the
+	// new string.
+	OldStart int
+	NewStart int
+
+	// The lines removed from the old string and added in the new.
+	Removed []string
+	Added   []string
+}
+
+// Diff compares a and b line by line and returns the hunks needed to turn a
+// into b. It returns an empty slice if the strings are equal.
+func (ns *Namespace) Diff(a, b interface{}) ([]DiffHunk, error) {
+	as, err := cast.ToStringE(a)
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := cast.ToStringE(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffLines(splitLines(as), splitLines(bs))
+}
+
+// maxDiffCells limits the size of the table used to diff the lines that
+// differ, which grows with the product of their line counts.
+const maxDiffCells = 1 << 22
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return _strings.Split(_strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines finds the longest common subsequence of a and b and reports
+// everything outside of it as hunks.
+func diffLines(a, b []string) ([]DiffHunk, error) {
+	// The lines in common at the start and the end are not part of any
+	// hunk, so leave them out of the table.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	a, b = a[prefix:], b[prefix:]
+
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	a, b = a[:len(a)-suffix], b[:len(b)-suffix]
+
+	if len(a) > 0 && len(b) > maxDiffCells/len(a) {
+		return nil, fmt.Errorf("too many changed lines to diff: %d old and %d new", len(a), len(b))
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	hunks := []DiffHunk{}
+	var current *DiffHunk
+
+	hunk := func(i, j int) *DiffHunk {
+		if current == nil {
+			current = &DiffHunk{OldStart: prefix + i + 1, NewStart: prefix + j + 1}
+		}
+		return current
+	}
+
+	flush := func() {
+		if current != nil {
+			hunks = append(hunks, *current)
+			current = nil
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			flush()
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			h := hunk(i, j)
+			h.Added = append(h.Added, b[j])
+			j++
+		default:
+			h := hunk(i, j)
+			h.Removed = append(h.Removed, a[i])
+			i++
+		}
+	}
+	flush()
+
+	return hunks, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"bytes"
+	"fmt"
+	_strings "strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	for i, test := range []struct {
+		a      interface{}
+		b      interface{}
+		expect interface{}
+	}{
+		{"a\nb\nc", "a\nb\nc", []DiffHunk{}},
+		{"", "", []DiffHunk{}},
+		{"a\nb\nc\n", "a\nB\nc\n", []DiffHunk{
+			{OldStart: 2, NewStart: 2, Removed: []string{"b"}, Added: []string{"B"}},
+		}},
+		{"a\nb\nc", "a\nb\nc\nd\ne", []DiffHunk{
+			{OldStart: 4, NewStart: 4, Added: []string{"d", "e"}},
+		}},
+		{"a\nb\nc\nd", "b\nc\nx", []DiffHunk{
+			{OldStart: 1, NewStart: 1, Removed: []string{"a"}},
+			{OldStart: 4, NewStart: 3, Removed: []string{"d"}, Added: []string{"x"}},
+		}},
+		{"", "a", []DiffHunk{
+			{OldStart: 1, NewStart: 1, Added: []string{"a"}},
+		}},
+		{t, "a", false},
+		{"a", t, false},
+	} {
+		errMsg := fmt.Sprintf("[%d] %v", i, test)
+
+		result, err := ns.Diff(test.a, test.b)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			require.Error(t, err, errMsg)
+			continue
+		}
+
+		require.NoError(t, err, errMsg)
+		assert.Equal(t, test.expect, result, errMsg)
+	}
+}
+
+func TestDiffLarge(t *testing.T) {
+	t.Parallel()
+
+	lines := func(prefix string, n int) string {
+		var b bytes.Buffer
+		for i := 0; i < n; i++ {
+			fmt.Fprintf(&b, "%s%d\n", prefix, i)
+		}
+		return b.String()
+	}
+
+	// Large, but only one line changed.
+	result, err := ns.Diff(lines("a", 20000), _strings.Replace(lines("a", 20000), "a100\n", "b100\n", 1))
+	require.NoError(t, err)
+	assert.Equal(t, []DiffHunk{{OldStart: 101, NewStart: 101, Removed: []string{"a100"}, Added: []string{"b100"}}}, result)
+
+	_, err = ns.Diff(lines("a", 10000), lines("b", 10000))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "too many changed lines")
+}
@@ -51,6 +51,13 @@ func init() {
 			[][2]string{},
 		)
 
+		ns.AddMethodMapping(ctx.Diff,
+			nil,
+			[][2]string{
+				{`{{ range strings.Diff "a\nb\nc" "a\nB\nc" }}{{ .OldStart }}: {{ .Removed }} {{ .Added }}{{ end }}`, `2: [b] [B]`},
+			},
+		)
+
 		ns.AddMethodMapping(ctx.FindRE,
 			[]string{"findRE"},
 			[][2]string{
@@ -25,7 +25,9 @@ import (
 )
 
 var (
-	tagRE        = regexp.MustCompile(`^<(/)?([^ ]+?)(?:(\s*/)| .*?)?>`)
+	tagRE        = regexp.MustCompile(`^<(/)?([^\s/>]+)(?:\s[^>]*?)?(/)?>`)
+	commentRE    = regexp.MustCompile(`^<!--(?s:.*?)-->`)
+	entityRE     = regexp.MustCompile(`^&(?:#[0-9]+|#[xX][0-9a-fA-F]+|[a-zA-Z][a-zA-Z0-9]*);`)
 	htmlSinglets = map[string]bool{
 		"br": true, "col": true, "link": true,
 		"base": true, "img": true, "param": true,
@@ -40,6 +42,10 @@ type htmlTag struct {
 }
 
 // Truncate truncates a given string to the specified length.
+//
+// If the string is HTML, e.g. the output of markdownify, only the text is
+// counted: tags and comments are kept whole, an entity such as &amp; counts
+// as a single character and any tags left open are closed.
 func (ns *Namespace) Truncate(a interface{}, options ...interface{}) (template.HTML, error) {
 	length, err := cast.ToIntE(a)
 	if err != nil {
@@ -91,9 +97,21 @@ func (ns *Namespace) Truncate(a interface{}, options ...interface{}) (template.H
 			continue
 		}
 
+		runeLen := utf8.RuneLen(r)
+
 		if isHTML {
 			// Make sure we keep tag of HTML tags
 			slice := text[i:]
+			if m := commentRE.FindStringIndex(slice); m != nil {
+				nextTag = i + m[1]
+				continue
+			}
+			if r == '&' {
+				if m := entityRE.FindStringIndex(slice); m != nil {
+					runeLen = m[1]
+					nextTag = i + runeLen
+				}
+			}
 			m := tagRE.FindStringSubmatchIndex(slice)
 			if len(m) > 0 && m[0] == 0 {
 				nextTag = i + m[1]
@@ -114,7 +132,7 @@ func (ns *Namespace) Truncate(a interface{}, options ...interface{}) (template.H
 		} else if unicode.In(r, unicode.Han, unicode.Hangul, unicode.Hiragana, unicode.Katakana) {
 			lastWordIndex = i
 		} else {
-			lastNonSpace = i + utf8.RuneLen(r)
+			lastNonSpace = i + runeLen
 		}
 
 		if currentLen > length {
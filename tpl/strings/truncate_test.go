@@ -47,6 +47,13 @@ func TestTruncate(t *testing.T) {
 		{3, template.HTML(strings.Repeat("<p>P</p>", 20)), nil, template.HTML("<p>P</p><p>P</p><p>P …</p>"), false},
 		{18, template.HTML("<p>test <b>hello</b> test something</p>"), nil, template.HTML("<p>test <b>hello</b> test …</p>"), false},
 		{4, template.HTML("<p>a<b><i>b</b>c d e</p>"), nil, template.HTML("<p>a<b><i>b</b>c …</p>"), false},
+		{8, template.HTML("<p><em>one <strong>two three</strong> four</em> five</p>"), nil, template.HTML("<p><em>one <strong>two …</strong></em></p>"), false},
+		{6, template.HTML("<ul><li>one</li><li>two</li><li>three</li></ul>"), nil, template.HTML("<ul><li>one</li><li>two …</li></ul>"), false},
+		{5, template.HTML("<p>Tom &amp; Jerry</p>"), nil, template.HTML("<p>Tom &amp; …</p>"), false},
+		{4, template.HTML("<p>a&#43;b&#x2B;c&nbsp;d</p>"), nil, template.HTML("<p>a&#43;b&#x2B; …</p>"), false},
+		{5, template.HTML("<div\nclass=\"x\">hello world</div>"), nil, template.HTML("<div\nclass=\"x\">hello …</div>"), false},
+		{4, template.HTML("<p>a <!-- <b>not counted</b> --> b c d</p>"), nil, template.HTML("<p>a <!-- <b>not counted</b> --> b …</p>"), false},
+		{3, template.HTML("<p>one<br/>two three</p>"), nil, template.HTML("<p>one …</p>"), false},
 		{10, nil, nil, template.HTML(""), true},
 		{nil, nil, nil, template.HTML(""), true},
 	}
@@ -82,6 +82,9 @@ type TemplateDebugger interface {
 type TemplateAdapter struct {
 	Template
 	Metrics metrics.Provider
+
+	// Usage, if set, records that this template was executed.
+	Usage *TemplateUsage
 }
 
 // Execute executes the current template. The actual execution is performed
@@ -91,6 +94,7 @@ func (t *TemplateAdapter) Execute(w io.Writer, data interface{}) error {
 	if t.Metrics != nil {
 		defer t.Metrics.MeasureSince(t.Name(), time.Now())
 	}
+	t.Usage.MarkUsed(t.Name())
 	return t.Template.Execute(w, data)
 }
 
@@ -0,0 +1,108 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tpl
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TemplateUsage tracks which of the templates loaded from the layouts
+// folders are executed, so the ones never used can be reported.
+type TemplateUsage struct {
+	mu     sync.Mutex
+	loaded map[string][]string
+	used   map[string]bool
+}
+
+// NewTemplateUsage creates a new, empty TemplateUsage.
+func NewTemplateUsage() *TemplateUsage {
+	return &TemplateUsage{loaded: make(map[string][]string), used: make(map[string]bool)}
+}
+
+// Add registers a template loaded from the layouts folders. The refs are the
+// templates it calls with the template action, e.g.
+// {{ template "partials/menu.html" . }}. Go's template package executes these
+// itself, so they are counted as used when the calling template is.
+func (u *TemplateUsage) Add(name string, refs ...string) {
+	if u == nil {
+		return
+	}
+	u.mu.Lock()
+	u.loaded[name] = refs
+	u.mu.Unlock()
+}
+
+// MarkUsed records that the template with the given name was executed.
+func (u *TemplateUsage) MarkUsed(name string) {
+	if u == nil {
+		return
+	}
+	u.mu.Lock()
+	u.used[name] = true
+	u.mu.Unlock()
+}
+
+// Reset clears the executed templates, e.g. before a rebuild.
+func (u *TemplateUsage) Reset() {
+	if u == nil {
+		return
+	}
+	u.mu.Lock()
+	u.used = make(map[string]bool)
+	u.mu.Unlock()
+}
+
+// Unused returns the sorted names of the templates added but never executed,
+// directly or with the template action from an executed template.
+// A template executed by its name without the extension, as is done for
+// shortcodes, counts as used. A template action counts even if it is in a
+// branch that is never taken.
+func (u *TemplateUsage) Unused() []string {
+	if u == nil {
+		return nil
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	used := make(map[string]bool)
+	var markUsed func(name string)
+	markUsed = func(name string) {
+		if used[name] {
+			return
+		}
+		used[name] = true
+		for _, ref := range u.loaded[name] {
+			markUsed(ref)
+		}
+	}
+
+	for name := range u.loaded {
+		if u.used[name] || u.used[strings.TrimSuffix(name, path.Ext(name))] {
+			markUsed(name)
+		}
+	}
+
+	var unused []string
+	for name := range u.loaded {
+		if !used[name] {
+			unused = append(unused, name)
+		}
+	}
+	sort.Strings(unused)
+
+	return unused
+}
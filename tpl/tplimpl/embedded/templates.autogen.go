@@ -44,6 +44,12 @@ var EmbeddedTemplates = [][2]string{
     {{ end }}
   </channel>
 </rss>`},
+	{`_default/searchindex.json`, `[
+{{- range $i, $p := .Site.RegularPages }}{{ if $i }},{{ end }}
+  {"title": {{ .Title | jsonify }}, "content": {{ .Plain | jsonify }}, "tags": {{ with .Params.tags }}{{ . | jsonify }}{{ else }}[]{{ end }}, "url": {{ .Permalink | jsonify }}}
+{{- end }}
+]
+`},
 	{`_default/sitemap.xml`, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"
   xmlns:xhtml="http://www.w3.org/1999/xhtml">
   {{ range .Data.Pages }}
@@ -75,6 +81,8 @@ var EmbeddedTemplates = [][2]string{
 	</sitemap>
 	{{ end }}
 </sitemapindex>
+`},
+	{`canonical.html`, `{{ with .CanonicalURL }}<link rel="canonical" href="{{ . }}" />{{ end }}
 `},
 	{`disqus.html`, `{{- $pc := .Page.Site.Config.Privacy.Disqus -}}
 {{- if not $pc.Disable -}}
@@ -170,13 +178,65 @@ if (!doNotTrack) {
 	{`google_news.html`, `{{ if .IsPage }}{{ with .Params.news_keywords }}
   <meta name="news_keywords" content="{{ range $i, $kw := first 10 . }}{{ if $i }},{{ end }}{{ $kw }}{{ end }}" />
 {{ end }}{{ end }}`},
+	{`jsonld.html`, `{{- $ISO8601 := "2006-01-02T15:04:05-07:00" -}}
+<script type="application/ld+json">
+{
+  "@context": "https://schema.org",
+  "@type": {{ if .IsPage }}"BlogPosting"{{ else }}"WebSite"{{ end }},
+  {{ if .IsPage }}"headline"{{ else }}"name"{{ end }}: {{ .Title }},
+  "url": {{ .CanonicalURL }},
+  {{- with .Description }}
+  "description": {{ . }},
+  {{- else }}{{ with .Site.Params.description }}
+  "description": {{ . }},
+  {{- end }}{{ end }}
+  {{- if .IsPage }}
+  {{- if not .PublishDate.IsZero }}
+  "datePublished": {{ .PublishDate.Format $ISO8601 }},
+  {{- else if not .Date.IsZero }}
+  "datePublished": {{ .Date.Format $ISO8601 }},
+  {{- end }}
+  {{- if not .Lastmod.IsZero }}
+  "dateModified": {{ .Lastmod.Format $ISO8601 }},
+  {{- end }}
+  {{- with .Params.author }}
+  "author": { "@type": "Person", "name": {{ . }} },
+  {{- else }}{{ with .Site.Author.name }}
+  "author": { "@type": "Person", "name": {{ . }} },
+  {{- end }}{{ end }}
+  {{- with .Params.images }}
+  "image": {{ index . 0 | absURL }},
+  {{- end }}
+  {{- with .Params.tags }}
+  "keywords": {{ delimit . ", " }},
+  {{- end }}
+  "wordCount": {{ .WordCount }},
+  {{- end }}
+  "inLanguage": {{ .Site.LanguageCode | default .Lang }}
+}
+</script>
+`},
 	{`opengraph.html`, `<meta property="og:title" content="{{ .Title }}" />
 <meta property="og:description" content="{{ with .Description }}{{ . }}{{ else }}{{if .IsPage}}{{ .Summary }}{{ else }}{{ with .Site.Params.description }}{{ . }}{{ end }}{{ end }}{{ end }}" />
 <meta property="og:type" content="{{ if .IsPage }}article{{ else }}website{{ end }}" />
-<meta property="og:url" content="{{ .Permalink }}" />
-{{ with .Params.images }}{{ range first 6 . }}
-  <meta property="og:image" content="{{ . | absURL }}" />
-{{ end }}{{ end }}
+<meta property="og:url" content="{{ .CanonicalURL }}" />
+{{- with $.Params.images -}}
+{{- range first 6 . }}
+<meta property="og:image" content="{{ . | absURL }}" />
+{{ end -}}
+{{- else -}}
+{{- $images := $.Resources.ByType "image" -}}
+{{- $featured := $images.GetMatch "*feature*" -}}
+{{- $featured := cond (ne $featured nil) $featured ($images.GetMatch "{*cover*,*thumbnail*}") -}}
+{{- $featured := cond (ne $featured nil) $featured ($images.GetMatch "**") -}}
+{{- with $featured }}
+<meta property="og:image" content="{{ $featured.Permalink }}" />
+{{ else -}}
+{{- with $.Site.Params.images }}
+<meta property="og:image" content="{{ index . 0 | absURL }}" />
+{{ end -}}
+{{- end -}}
+{{- end }}
 
 {{ if .IsPage }}
 {{ if not .PublishDate.IsZero }}<meta property="article:published_time" content="{{ .PublishDate.Format "2006-01-02T15:04:05-07:00" | safeHTML }}"/>
@@ -387,20 +447,30 @@ if (!doNotTrack) {
 {{- if $pc.Simple -}}
 {{ template "_internal/shortcodes/twitter_simple.html" . }}
 {{- else -}}
-{{- $url := printf "https://api.twitter.com/1/statuses/oembed.json?id=%s&dnt=%t" (index .Params 0) $pc.EnableDNT -}}
-{{- $json := getJSON $url -}}
-{{ $json.html | safeHTML }}
+{{- $id := index .Params 0 -}}
+{{- $url := printf "https://api.twitter.com/1/statuses/oembed.json?id=%s&dnt=%t" $id $pc.EnableDNT -}}
+{{- with data.TryGetJSON $url -}}
+{{ .html | safeHTML }}
+{{- else -}}
+{{- $link := printf "https://twitter.com/i/web/status/%s" $id -}}
+<blockquote class="twitter-tweet"><a href="{{ $link }}">{{ $link }}</a></blockquote>
+{{- end -}}
 {{- end -}}
 {{- end -}}`},
 	{`shortcodes/twitter_simple.html`, `{{- $pc := .Page.Site.Config.Privacy.Twitter -}}
 {{- $sc := .Page.Site.Config.Services.Twitter -}}
 {{- if not $pc.Disable -}}
 {{- $id := .Get 0 -}}
-{{- $json := getJSON "https://api.twitter.com/1/statuses/oembed.json?id=" $id "&omit_script=true" -}}
+{{- $json := data.TryGetJSON "https://api.twitter.com/1/statuses/oembed.json?id=" $id "&omit_script=true" -}}
 {{- if not $sc.DisableInlineCSS -}}
 {{ template "__h_simple_twitter_css" $ }}
 {{- end -}}
-{{ $json.html | safeHTML }}
+{{- with $json -}}
+{{ .html | safeHTML }}
+{{- else -}}
+{{- $link := printf "https://twitter.com/i/web/status/%s" $id -}}
+<blockquote class="twitter-tweet"><a href="{{ $link }}">{{ $link }}</a></blockquote>
+{{- end -}}
 {{- end -}}
 
 {{ define "__h_simple_twitter_css" }}
@@ -475,6 +545,7 @@ if (!doNotTrack) {
 {{- $images := $.Resources.ByType "image" -}}
 {{- $featured := $images.GetMatch "*feature*" -}}
 {{- $featured := cond (ne $featured nil) $featured ($images.GetMatch "{*cover*,*thumbnail*}") -}}
+{{- $featured := cond (ne $featured nil) $featured ($images.GetMatch "**") -}}
 {{- with $featured -}}
 <meta name="twitter:card" content="summary_large_image"/>
 <meta name="twitter:image" content="{{ $featured.Permalink }}"/>
@@ -231,7 +231,7 @@ func (t *htmlTemplates) Lookup(name string) (tpl.Template, bool) {
 	if templ == nil {
 		return nil, false
 	}
-	return &tpl.TemplateAdapter{Template: templ, Metrics: t.funcster.Deps.Metrics}, true
+	return &tpl.TemplateAdapter{Template: templ, Metrics: t.funcster.Deps.Metrics, Usage: t.funcster.Deps.TemplateUsage}, true
 }
 
 func (t *htmlTemplates) lookup(name string) *template.Template {
@@ -272,7 +272,7 @@ func (t *textTemplates) Lookup(name string) (tpl.Template, bool) {
 	if templ == nil {
 		return nil, false
 	}
-	return &tpl.TemplateAdapter{Template: templ, Metrics: t.funcster.Deps.Metrics}, true
+	return &tpl.TemplateAdapter{Template: templ, Metrics: t.funcster.Deps.Metrics, Usage: t.funcster.Deps.TemplateUsage}, true
 }
 
 func (t *textTemplates) lookup(name string) *texttemplate.Template {
@@ -496,6 +496,14 @@ func (t *templateHandler) loadTemplates(prefix string) {
 
 		if err := t.addTemplateFile(tplID.Name, tplID.MasterFilename, tplID.OverlayFilename); err != nil {
 			t.Log.ERROR.Printf("Failed to add template %q in path %q: %s", tplID.Name, path, err)
+		} else if t.TemplateUsage != nil {
+			name, lookupName := strings.TrimPrefix(tplID.Name, textTmplNamePrefix), tplID.Name
+			if ext := filepath.Ext(path); ext == ".amber" || ext == ".ace" {
+				// These are added as HTML templates.
+				name = strings.TrimSuffix(name, ext) + ".html"
+				lookupName = name
+			}
+			t.TemplateUsage.Add(name, t.templateRefs(lookupName)...)
 		}
 
 		return nil
@@ -720,10 +728,19 @@ var embeddedTemplatesAliases = map[string][]string{
 	"shortcodes/twitter.html": []string{"shortcodes/tweet.html"},
 }
 
+// The embedded templates to parse with text/template.
+var embeddedTextTemplates = map[string]bool{
+	"_default/searchindex.json": true,
+}
+
 func (t *templateHandler) loadEmbedded() {
 	for _, kv := range embedded.EmbeddedTemplates {
 		// TODO(bep) error handling
 		name, templ := kv[0], kv[1]
+		if embeddedTextTemplates[name] {
+			t.AddTemplate(textTmplNamePrefix+"_internal/"+name, templ)
+			continue
+		}
 		t.addInternalTemplate(name, templ)
 		if aliases, found := embeddedTemplatesAliases[name]; found {
 			for _, alias := range aliases {
@@ -19,6 +19,8 @@ import (
 	"strings"
 	texttemplate "text/template"
 	"text/template/parse"
+
+	"github.com/gohugoio/hugo/tpl"
 )
 
 // decl keeps track of the variable mappings, i.e. $mysite => .Site etc.
@@ -64,6 +66,77 @@ func createParseTreeLookup(templ *template.Template) func(nn string) *parse.Tree
 	}
 }
 
+// templateRefs returns the names of the templates called with the template
+// action from the named template, including from the templates defined in it,
+// e.g. {{ define "main" }}.
+func (t *templateHandler) templateRefs(name string) []string {
+	templ, found := t.Lookup(name)
+	if !found {
+		return nil
+	}
+
+	var (
+		tree     *parse.Tree
+		lookupFn func(name string) *parse.Tree
+	)
+
+	switch tt := templ.(*tpl.TemplateAdapter).Template.(type) {
+	case *template.Template:
+		tree, lookupFn = tt.Tree, createParseTreeLookup(tt)
+	case *texttemplate.Template:
+		tree, lookupFn = tt.Tree, func(nn string) *parse.Tree {
+			if ttt := tt.Lookup(nn); ttt != nil {
+				return ttt.Tree
+			}
+			return nil
+		}
+	}
+
+	if tree == nil {
+		return nil
+	}
+
+	var (
+		refs    []string
+		visited = map[string]bool{name: true}
+		walk    func(n parse.Node)
+	)
+
+	walk = func(n parse.Node) {
+		switch x := n.(type) {
+		case *parse.ListNode:
+			if x == nil {
+				return
+			}
+			for _, nn := range x.Nodes {
+				walk(nn)
+			}
+		case *parse.IfNode:
+			walk(x.List)
+			walk(x.ElseList)
+		case *parse.RangeNode:
+			walk(x.List)
+			walk(x.ElseList)
+		case *parse.WithNode:
+			walk(x.List)
+			walk(x.ElseList)
+		case *parse.TemplateNode:
+			if visited[x.Name] {
+				return
+			}
+			visited[x.Name] = true
+			refs = append(refs, x.Name)
+			if tree := lookupFn(x.Name); tree != nil {
+				walk(tree.Root)
+			}
+		}
+	}
+
+	walk(tree.Root)
+
+	return refs
+}
+
 func applyTemplateTransformersToHMLTTemplate(templ *template.Template) error {
 	return applyTemplateTransformers(templ.Tree, createParseTreeLookup(templ))
 }
@@ -0,0 +1,98 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// GPX holds the waypoints, routes and tracks of a GPX document.
+type GPX struct {
+	XMLName   xml.Name   `xml:"gpx"`
+	Name      string     `xml:"metadata>name"`
+	Waypoints []GPXPoint `xml:"wpt"`
+	Routes    []GPXRoute `xml:"rte"`
+	Tracks    []GPXTrack `xml:"trk"`
+}
+
+// GPXPoint is a waypoint, a route point or a track point.
+type GPXPoint struct {
+	Lat         float64   `xml:"lat,attr"`
+	Lon         float64   `xml:"lon,attr"`
+	Elevation   float64   `xml:"ele"`
+	Time        time.Time `xml:"time"`
+	Name        string    `xml:"name"`
+	Description string    `xml:"desc"`
+}
+
+// GPXRoute is an ordered list of route points leading to a destination.
+type GPXRoute struct {
+	Name   string     `xml:"name"`
+	Points []GPXPoint `xml:"rtept"`
+}
+
+// GPXTrack is a recorded track made up of one or more segments.
+type GPXTrack struct {
+	Name     string            `xml:"name"`
+	Segments []GPXTrackSegment `xml:"trkseg"`
+}
+
+// GPXTrackSegment is a continuous span of track points.
+type GPXTrackSegment struct {
+	Points []GPXPoint `xml:"trkpt"`
+}
+
+// Points returns the track points of all the segments in this track.
+func (t GPXTrack) Points() []GPXPoint {
+	var points []GPXPoint
+	for _, s := range t.Segments {
+		points = append(points, s.Points...)
+	}
+	return points
+}
+
+// contentProvider is implemented by resources that can provide their content,
+// e.g. a GPX file fetched with resources.Get or .Resources.GetMatch.
+type contentProvider interface {
+	Content() (interface{}, error)
+}
+
+// GPX parses the given GPX document, either a string or a resource, and
+// returns its waypoints, routes and tracks.
+func (ns *Namespace) GPX(data interface{}) (*GPX, error) {
+	if r, ok := data.(contentProvider); ok {
+		c, err := r.Content()
+		if err != nil {
+			return nil, err
+		}
+		data = c
+	}
+
+	s, err := cast.ToStringE(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var gpx GPX
+	if err := xml.NewDecoder(strings.NewReader(s)).Decode(&gpx); err != nil {
+		return nil, fmt.Errorf("failed to parse GPX: %s", err)
+	}
+
+	return &gpx, nil
+}
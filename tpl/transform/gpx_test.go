@@ -0,0 +1,81 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+type tstContentProvider string
+
+func (c tstContentProvider) Content() (interface{}, error) {
+	return string(c), nil
+}
+
+func TestGPX(t *testing.T) {
+	t.Parallel()
+
+	v := viper.New()
+	v.Set("contentDir", "content")
+	ns := New(newDeps(v))
+	assert := require.New(t)
+
+	b, err := ioutil.ReadFile(filepath.Join("testdata", "trip.gpx"))
+	assert.NoError(err)
+
+	for _, data := range []interface{}{string(b), tstContentProvider(b)} {
+		gpx, err := ns.GPX(data)
+		assert.NoError(err)
+
+		assert.Equal("Hardangervidda", gpx.Name)
+		assert.Len(gpx.Waypoints, 2)
+		assert.Equal("Finse", gpx.Waypoints[0].Name)
+		assert.Equal(60.3913, gpx.Waypoints[0].Lat)
+		assert.Equal(7.5117, gpx.Waypoints[0].Lon)
+		assert.Equal(float64(1240), gpx.Waypoints[0].Elevation)
+		assert.Equal("Cabin", gpx.Waypoints[1].Description)
+
+		assert.Len(gpx.Routes, 1)
+		assert.Len(gpx.Routes[0].Points, 2)
+
+		assert.Len(gpx.Tracks, 1)
+		assert.Len(gpx.Tracks[0].Segments, 2)
+		points := gpx.Tracks[0].Points()
+		assert.Len(points, 3)
+		assert.Equal(time.Date(2018, 7, 1, 15, 0, 0, 0, time.UTC), points[2].Time)
+	}
+}
+
+func TestGPXInvalid(t *testing.T) {
+	t.Parallel()
+
+	v := viper.New()
+	v.Set("contentDir", "content")
+	ns := New(newDeps(v))
+	assert := require.New(t)
+
+	_, err := ns.GPX(`<gpx><wpt lat="60.3" lon="7.5"></gpx>`)
+	assert.Error(err)
+	assert.Contains(err.Error(), "failed to parse GPX")
+
+	_, err = ns.GPX(`<kml></kml>`)
+	assert.Error(err)
+	assert.Contains(err.Error(), "failed to parse GPX")
+}
@@ -41,6 +41,16 @@ func init() {
 			[][2]string{},
 		)
 
+		ns.AddMethodMapping(ctx.HighlightCodeBlock,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.GPX,
+			nil,
+			[][2]string{},
+		)
+
 		ns.AddMethodMapping(ctx.HTMLEscape,
 			[]string{"htmlEscape"},
 			[][2]string{
@@ -15,11 +15,17 @@ package transform
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"html"
 	"html/template"
+	"sort"
+	"strings"
 
 	"github.com/gohugoio/hugo/deps"
 	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/tpl"
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/cast"
 )
 
@@ -48,17 +54,166 @@ func (ns *Namespace) Emojify(s interface{}) (template.HTML, error) {
 }
 
 // Highlight returns a copy of s as an HTML string with syntax
-// highlighting applied.
-func (ns *Namespace) Highlight(s interface{}, lang, opts string) (template.HTML, error) {
+// highlighting applied. The options can be given as a string, e.g.
+// "linenos=table,hl_lines=1-3 5", or as a map, e.g.
+// (dict "linenos" "table" "hl_lines" (slice "1-3" 5)).
+func (ns *Namespace) Highlight(s interface{}, lang string, opts interface{}) (template.HTML, error) {
 	ss, err := cast.ToStringE(s)
 	if err != nil {
 		return "", err
 	}
 
-	highlighted, _ := ns.deps.ContentSpec.Highlight(ss, lang, opts)
+	optsStr, err := highlightOptionsString(opts)
+	if err != nil {
+		return "", err
+	}
+
+	highlighted, _ := ns.deps.ContentSpec.Highlight(ss, lang, optsStr)
 	return template.HTML(highlighted), nil
 }
 
+// HighlightCodeBlock highlights the code in a code block render hook context,
+// using the code block's language and attributes as options:
+//
+//	{{ transform.HighlightCodeBlock . }}
+//
+// It can also highlight a code string, e.g. from a data file, in which case
+// the language must be given as the second argument:
+//
+//	{{ transform.HighlightCodeBlock .Site.Data.snippets.hello "go" }}
+//
+// Any options given last, as a string or a map, win over the code block's
+// attributes.
+func (ns *Namespace) HighlightCodeBlock(ctx interface{}, args ...interface{}) (template.HTML, error) {
+	var (
+		code, lang string
+		options    = make(map[string]string)
+	)
+
+	if v, ok := ctx.(helpers.CodeBlockContext); ok {
+		ctx = &v
+	}
+
+	if cb, ok := ctx.(*helpers.CodeBlockContext); ok {
+		code, lang = cb.Code, cb.Lang
+		for k, v := range cb.Attributes {
+			// Attributes can be anything, so skip the ones
+			// that are not highlighting options.
+			if helpers.IsPygmentsOption(k) {
+				options[strings.ToLower(k)] = v
+			}
+		}
+	} else {
+		var err error
+		if code, err = cast.ToStringE(ctx); err != nil {
+			return "", err
+		}
+		if len(args) == 0 {
+			return "", errors.New("must provide a language when highlighting a string")
+		}
+		if lang, err = cast.ToStringE(args[0]); err != nil {
+			return "", err
+		}
+		args = args[1:]
+	}
+
+	if len(args) > 1 {
+		return "", errors.New("too many arguments")
+	}
+
+	if len(args) == 1 {
+		m, err := highlightOptionsMap(args[0])
+		if err != nil {
+			return "", err
+		}
+		for k, v := range m {
+			options[k] = v
+		}
+	}
+
+	highlighted, _ := ns.deps.ContentSpec.Highlight(code, lang, joinHighlightOptions(options))
+	return template.HTML(highlighted), nil
+}
+
+// highlightOptionsString converts the highlight options to the
+// comma separated key=value format used by Pygments.
+func highlightOptionsString(opts interface{}) (string, error) {
+	if s, ok := opts.(string); ok {
+		return s, nil
+	}
+
+	m, err := highlightOptionsMap(opts)
+	if err != nil {
+		return "", err
+	}
+
+	return joinHighlightOptions(m), nil
+}
+
+// highlightOptionsMap converts the highlight options, a string or a map, to
+// a map with lower case keys.
+func highlightOptionsMap(opts interface{}) (map[string]string, error) {
+	options := make(map[string]string)
+
+	if opts == nil {
+		return options, nil
+	}
+
+	if s, ok := opts.(string); ok {
+		for _, kv := range strings.Split(s, ",") {
+			if strings.TrimSpace(kv) == "" {
+				continue
+			}
+			parts := strings.SplitN(kv, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid highlight option %q", kv)
+			}
+			options[strings.ToLower(strings.TrimSpace(parts[0]))] = parts[1]
+		}
+		return options, nil
+	}
+
+	m, err := cast.ToStringMapE(opts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid highlight options: %s", err)
+	}
+
+	for k, vv := range m {
+		var v string
+		switch vv.(type) {
+		case []interface{}, []string, []int:
+			// A list of lines or line ranges, e.g. hl_lines.
+			values, err := cast.ToStringSliceE(vv)
+			if err != nil {
+				return nil, fmt.Errorf("invalid highlight option %q: %s", k, err)
+			}
+			v = strings.Join(values, " ")
+		default:
+			if v, err = cast.ToStringE(vv); err != nil {
+				return nil, fmt.Errorf("invalid highlight option %q: %s", k, err)
+			}
+		}
+		options[strings.ToLower(k)] = v
+	}
+
+	return options, nil
+}
+
+func joinHighlightOptions(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	options := make([]string, len(keys))
+	for i, k := range keys {
+		options[i] = k + "=" + m[k]
+	}
+
+	return strings.Join(options, ",")
+}
+
 // HTMLEscape returns a copy of s with reserved HTML characters escaped.
 func (ns *Namespace) HTMLEscape(s interface{}) (string, error) {
 	ss, err := cast.ToStringE(s)
@@ -86,21 +241,51 @@ var (
 	markdownParagraphIndicator = []byte("<p")
 )
 
-// Markdownify renders a given input from Markdown to HTML.
-func (ns *Namespace) Markdownify(s interface{}) (template.HTML, error) {
-	ss, err := cast.ToStringE(s)
+// markdownifyOpts configures Markdownify.
+type markdownifyOpts struct {
+	// Whether to apply the link and image render hooks. Default is false.
+	RenderHooks bool
+}
+
+// Markdownify renders a given input from Markdown to HTML. An options map
+// can be given as the first argument:
+//
+//	{{ .Title | markdownify }}
+//	{{ .Title | markdownify (dict "renderHooks" true) }}
+func (ns *Namespace) Markdownify(args ...interface{}) (template.HTML, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return "", errors.New("want 1 or 2 arguments")
+	}
+
+	var opts markdownifyOpts
+
+	if len(args) == 2 {
+		m, err := cast.ToStringMapE(args[0])
+		if err != nil {
+			return "", fmt.Errorf("invalid options: %s", err)
+		}
+		if err := mapstructure.WeakDecode(m, &opts); err != nil {
+			return "", fmt.Errorf("invalid options: %s", err)
+		}
+	}
+
+	ss, err := cast.ToStringE(args[len(args)-1])
 	if err != nil {
 		return "", err
 	}
 
-	m := ns.deps.ContentSpec.RenderBytes(
-		&helpers.RenderingContext{
-			Cfg:     ns.deps.Cfg,
-			Content: []byte(ss),
-			PageFmt: "markdown",
-			Config:  ns.deps.ContentSpec.BlackFriday,
-		},
-	)
+	ctx := &helpers.RenderingContext{
+		Cfg:     ns.deps.Cfg,
+		Content: []byte(ss),
+		PageFmt: "markdown",
+		Config:  ns.deps.ContentSpec.BlackFriday,
+	}
+
+	if opts.RenderHooks {
+		ctx.RenderHooks = tpl.NewRenderHooks(ns.deps.Tmpl, nil)
+	}
+
+	m := ns.deps.ContentSpec.RenderBytes(ctx)
 
 	// Strip if this is a short inline type of text.
 	first := bytes.Index(m, markdownParagraphIndicator)
@@ -67,16 +67,24 @@ func TestHighlight(t *testing.T) {
 	v.Set("contentDir", "content")
 	ns := New(newDeps(v))
 
+	code := "a := 1\nb := 2\nc := 3\nd := 4"
+
 	for i, test := range []struct {
 		s      interface{}
 		lang   string
-		opts   string
+		opts   interface{}
 		expect interface{}
 	}{
 		{"func boo() {}", "go", "", "boo"},
 		// Issue #4179
 		{`<Foo attr=" &lt; "></Foo>`, "xml", "", `&amp;lt;`},
 		{tstNoStringer{}, "go", "", false},
+		{code, "go", "noclasses=false,linenos=inline,hl_lines=1-2", `<span class="hl"><span class="ln">2</span>`},
+		{code, "go", map[string]interface{}{"noclasses": false, "linenos": "inline", "hl_lines": "2-3"}, `<span class="hl"><span class="ln">3</span>`},
+		{code, "go", map[string]interface{}{"noclasses": false, "hl_lines": []interface{}{1, "3-4"}}, `<span class="hl"><span class="nx">d</span>`},
+		{code, "go", map[string]interface{}{"noclasses": false, "linenos": "table"}, `<td class="lntd">`},
+		{code, "go", map[string]interface{}{"noclasses": false, "linenos": false}, `data-lang="go"><span class="nx">a</span>`},
+		{code, "go", tstNoStringer{}, false},
 	} {
 		errMsg := fmt.Sprintf("[%d]", i)
 
@@ -92,6 +100,51 @@ func TestHighlight(t *testing.T) {
 	}
 }
 
+func TestHighlightCodeBlock(t *testing.T) {
+	t.Parallel()
+
+	v := viper.New()
+	v.Set("contentDir", "content")
+	v.Set("pygmentsUseClasses", true)
+	ns := New(newDeps(v))
+
+	code := "func main() {\n\tfmt.Println(\"Hugo\")\n}"
+	cb := helpers.CodeBlockContext{
+		Lang:       "go",
+		Code:       code,
+		Attributes: map[string]string{"hl_lines": "2", "copy": "true"},
+	}
+
+	for i, test := range []struct {
+		ctx    interface{}
+		args   []interface{}
+		expect interface{}
+	}{
+		{code, []interface{}{"go"}, `<span class="kd">func</span> <span class="nx">main</span><span class="p">()</span>`},
+		{code, []interface{}{"go", "linenos=inline"}, `<span class="ln">2</span>`},
+		{code, []interface{}{"go", map[string]interface{}{"hl_lines": "1"}}, `<span class="hl"><span class="kd">func</span>`},
+		{cb, nil, `<span class="hl">	<span class="nx">fmt</span>`},
+		{&cb, nil, `<span class="hl">	<span class="nx">fmt</span>`},
+		{cb, []interface{}{map[string]interface{}{"hl_lines": "3"}}, `<span class="hl"><span class="p">}</span>`},
+		{code, nil, false},
+		{code, []interface{}{"go", "linenos"}, false},
+		{cb, []interface{}{"linenos=table", "extra"}, false},
+		{tstNoStringer{}, []interface{}{"go"}, false},
+	} {
+		errMsg := fmt.Sprintf("[%d]", i)
+
+		result, err := ns.HighlightCodeBlock(test.ctx, test.args...)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			require.Error(t, err, errMsg)
+			continue
+		}
+
+		require.NoError(t, err, errMsg)
+		assert.Contains(t, result, test.expect.(string), errMsg)
+	}
+}
+
 func TestHTMLEscape(t *testing.T) {
 	t.Parallel()
 
@@ -181,6 +234,30 @@ func TestMarkdownify(t *testing.T) {
 	}
 }
 
+func TestMarkdownifyOptions(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	v := viper.New()
+	v.Set("contentDir", "content")
+	ns := New(newDeps(v))
+
+	// No templates, so there are no render hooks to apply.
+	result, err := ns.Markdownify(map[string]interface{}{"renderHooks": true}, "[Hugo](https://gohugo.io)")
+	assert.NoError(err)
+	assert.Equal(template.HTML(`<a href="https://gohugo.io">Hugo</a>`), result)
+
+	_, err = ns.Markdownify("invalid", "text")
+	assert.Error(err)
+
+	_, err = ns.Markdownify()
+	assert.Error(err)
+
+	_, err = ns.Markdownify(map[string]interface{}{}, "a", "b")
+	assert.Error(err)
+}
+
 // Issue #3040
 func TestMarkdownifyBlocksOfText(t *testing.T) {
 	t.Parallel()
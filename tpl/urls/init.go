@@ -38,6 +38,26 @@ func init() {
 			[]string{"absLangURL"},
 			[][2]string{},
 		)
+		ns.AddMethodMapping(ctx.JoinPath,
+			nil,
+			[][2]string{
+				{`{{ urls.JoinPath "a/" "/b" }}`, `a/b`},
+				{`{{ urls.JoinPath "https://example.org/docs/" "/functions/" }}`, `https://example.org/docs/functions/`},
+			},
+		)
+		ns.AddMethodMapping(ctx.Parse,
+			nil,
+			[][2]string{
+				{`{{ with urls.Parse "https://gohugo.io:8080/docs/?q=hugo#top" }}{{ .Hostname }}|{{ .Port }}|{{ .Path }}|{{ .Query.Get "q" }}|{{ .Fragment }}{{ end }}`, `gohugo.io|8080|/docs/|hugo|top`},
+			},
+		)
+		ns.AddMethodMapping(ctx.Querify,
+			nil,
+			[][2]string{
+				{`{{ urls.Querify "q" "test" "page" 3 }}`, `page=3&amp;q=test`},
+				{`{{ urls.Querify (dict "q" "test" "page" 3) | safeHTML }}`, `page=3&q=test`},
+			},
+		)
 		ns.AddMethodMapping(ctx.Ref,
 			[]string{"ref"},
 			[][2]string{},
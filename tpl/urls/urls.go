@@ -21,16 +21,20 @@ import (
 
 	"html/template"
 	"net/url"
+	"path"
+	"strings"
 
 	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/tpl/collections"
 	"github.com/spf13/cast"
 )
 
 // New returns a new instance of the urls-namespaced template functions.
 func New(deps *deps.Deps) *Namespace {
 	return &Namespace{
-		deps:      deps,
-		multihost: deps.Cfg.GetBool("multihost"),
+		deps:        deps,
+		multihost:   deps.Cfg.GetBool("multihost"),
+		collections: collections.New(deps),
 	}
 }
 
@@ -38,6 +42,9 @@ func New(deps *deps.Deps) *Namespace {
 type Namespace struct {
 	deps      *deps.Deps
 	multihost bool
+
+	// Querify lives in collections, where it was first added.
+	collections *collections.Namespace
 }
 
 // AbsURL takes a given string and converts it to an absolute URL.
@@ -61,6 +68,57 @@ func (ns *Namespace) Parse(rawurl interface{}) (*url.URL, error) {
 	return url.Parse(s)
 }
 
+// JoinPath joins the given path elements into a single URL path, removing
+// any duplicate slashes. The first element may be an absolute URL, e.g.
+// "https://example.org/docs/". A leading slash in the first element and a
+// trailing slash in the last are kept.
+func (ns *Namespace) JoinPath(elements ...interface{}) (string, error) {
+	if len(elements) == 0 {
+		return "", errors.New("JoinPath requires at least one element")
+	}
+
+	parts := make([]string, len(elements))
+	for i, e := range elements {
+		s, err := cast.ToStringE(e)
+		if err != nil {
+			return "", fmt.Errorf("Error in JoinPath: %s", err)
+		}
+		parts[i] = s
+	}
+
+	var base *url.URL
+	if u, err := url.Parse(parts[0]); err == nil && u.IsAbs() {
+		base = u
+		parts[0] = u.Path
+	}
+
+	joined := path.Join(parts...)
+	if joined == "." {
+		joined = ""
+	}
+	if strings.HasPrefix(parts[0], "/") || base != nil {
+		joined = "/" + strings.TrimPrefix(joined, "/")
+	}
+	if last := parts[len(parts)-1]; strings.HasSuffix(last, "/") && !strings.HasSuffix(joined, "/") {
+		joined += "/"
+	}
+
+	if base == nil {
+		return joined, nil
+	}
+
+	base.Path = joined
+	base.RawPath = ""
+
+	return base.String(), nil
+}
+
+// Querify encodes the given parameters in URL-encoded form ("bar=baz&foo=quux") sorted by key.
+// It is the same as collections.Querify.
+func (ns *Namespace) Querify(params ...interface{}) (string, error) {
+	return ns.collections.Querify(params...)
+}
+
 // RelURL takes a given string and prepends the relative path according to a
 // page's position in the project directory structure.
 func (ns *Namespace) RelURL(a interface{}) (template.HTML, error) {
@@ -96,25 +154,74 @@ type reflinker interface {
 }
 
 // Ref returns the absolute URL path to a given content item.
-func (ns *Namespace) Ref(in interface{}, refs ...string) (template.HTML, error) {
+// The reference is either given as strings, the path and an optional output
+// format, or as a map with "path" and "outputFormat" keys.
+func (ns *Namespace) Ref(in interface{}, args ...interface{}) (template.HTML, error) {
 	p, ok := in.(reflinker)
 	if !ok {
 		return "", errors.New("invalid Page received in Ref")
 	}
+	refs, err := refArgs(args)
+	if err != nil {
+		return "", err
+	}
 	s, err := p.Ref(refs...)
 	return template.HTML(s), err
 }
 
 // RelRef returns the relative URL path to a given content item.
-func (ns *Namespace) RelRef(in interface{}, refs ...string) (template.HTML, error) {
+// See Ref for the accepted arguments.
+func (ns *Namespace) RelRef(in interface{}, args ...interface{}) (template.HTML, error) {
 	p, ok := in.(reflinker)
 	if !ok {
 		return "", errors.New("invalid Page received in RelRef")
 	}
+	refs, err := refArgs(args)
+	if err != nil {
+		return "", err
+	}
 	s, err := p.RelRef(refs...)
 	return template.HTML(s), err
 }
 
+// refArgs converts the arguments given to Ref and RelRef to the path and
+// output format strings expected by the reflinker.
+func refArgs(args []interface{}) ([]string, error) {
+	if len(args) == 1 {
+		if m, ok := args[0].(map[string]interface{}); ok {
+			var path, outputFormat string
+			for k, v := range m {
+				s, err := cast.ToStringE(v)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %q in ref options: %s", k, err)
+				}
+				switch strings.ToLower(k) {
+				case "path":
+					path = s
+				case "outputformat":
+					outputFormat = s
+				default:
+					return nil, fmt.Errorf("unknown ref option %q", k)
+				}
+			}
+			if outputFormat == "" {
+				return []string{path}, nil
+			}
+			return []string{path, outputFormat}, nil
+		}
+	}
+
+	refs := make([]string, len(args))
+	for i, arg := range args {
+		s, err := cast.ToStringE(arg)
+		if err != nil {
+			return nil, err
+		}
+		refs[i] = s
+	}
+	return refs, nil
+}
+
 // RelLangURL takes a given string and prepends the relative path according to a
 // page's position in the project directory structure and the current language.
 func (ns *Namespace) RelLangURL(a interface{}) (template.HTML, error) {
@@ -15,7 +15,9 @@ package urls
 
 import (
 	"fmt"
+	"html/template"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/gohugoio/hugo/deps"
@@ -50,8 +52,27 @@ func TestParse(t *testing.T) {
 				Host:   "google.com",
 			},
 		},
+		{
+			"https://gohugo.io:8080/docs/functions/?q=hugo&lang=en#top",
+			&url.URL{
+				Scheme:   "https",
+				Host:     "gohugo.io:8080",
+				Path:     "/docs/functions/",
+				RawQuery: "q=hugo&lang=en",
+				Fragment: "top",
+			},
+		},
+		{
+			"/docs/?q=a%20b",
+			&url.URL{
+				Path:     "/docs/",
+				RawQuery: "q=a%20b",
+			},
+		},
 		// errors
 		{tstNoStringer{}, false},
+		{"http://[::1", false},
+		{"http://example.com/%zz", false},
 	} {
 		errMsg := fmt.Sprintf("[%d] %v", i, test)
 
@@ -66,3 +87,115 @@ func TestParse(t *testing.T) {
 		assert.Equal(t, test.expect, result, errMsg)
 	}
 }
+
+func TestJoinPath(t *testing.T) {
+	t.Parallel()
+
+	for i, test := range []struct {
+		elements []interface{}
+		expect   interface{}
+	}{
+		{[]interface{}{"a/", "/b"}, "a/b"},
+		{[]interface{}{"a", "b", "c"}, "a/b/c"},
+		{[]interface{}{"a//", "//b//", "c"}, "a/b/c"},
+		{[]interface{}{"/a/", "b/"}, "/a/b/"},
+		{[]interface{}{"a", "", "b"}, "a/b"},
+		{[]interface{}{"a", 2018, "post"}, "a/2018/post"},
+		{[]interface{}{"/"}, "/"},
+		{[]interface{}{"a/b", "../c"}, "a/c"},
+		{[]interface{}{"https://example.org", "docs"}, "https://example.org/docs"},
+		{[]interface{}{"https://example.org/", "/docs/"}, "https://example.org/docs/"},
+		{[]interface{}{"https://example.org/sub//", "/a b/", "c"}, "https://example.org/sub/a%20b/c"},
+		{[]interface{}{"https://example.org:1313/a?q=1", "b"}, "https://example.org:1313/a/b?q=1"},
+		// errors
+		{[]interface{}{}, false},
+		{[]interface{}{"a", tstNoStringer{}}, false},
+	} {
+		errMsg := fmt.Sprintf("[%d] %v", i, test)
+
+		result, err := ns.JoinPath(test.elements...)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			require.Error(t, err, errMsg)
+			continue
+		}
+
+		require.NoError(t, err, errMsg)
+		assert.Equal(t, test.expect, result, errMsg)
+	}
+}
+
+func TestQuerify(t *testing.T) {
+	t.Parallel()
+
+	for i, test := range []struct {
+		params []interface{}
+		expect interface{}
+	}{
+		{[]interface{}{"a", "b"}, "a=b"},
+		{[]interface{}{"q", "this&that", "page", 3}, "page=3&q=this%26that"},
+		{[]interface{}{map[string]interface{}{"q": "test", "page": 3}}, "page=3&q=test"},
+		// errors
+		{[]interface{}{"a"}, false},
+		{[]interface{}{5, "b"}, false},
+	} {
+		errMsg := fmt.Sprintf("[%d] %v", i, test)
+
+		result, err := ns.Querify(test.params...)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			require.Error(t, err, errMsg)
+			continue
+		}
+
+		require.NoError(t, err, errMsg)
+		assert.Equal(t, test.expect, result, errMsg)
+	}
+}
+
+type tstRefLinker struct{}
+
+func (tstRefLinker) Ref(refs ...string) (string, error) {
+	return "Ref:" + strings.Join(refs, "|"), nil
+}
+
+func (tstRefLinker) RelRef(refs ...string) (string, error) {
+	return "RelRef:" + strings.Join(refs, "|"), nil
+}
+
+func TestRef(t *testing.T) {
+	t.Parallel()
+
+	p := tstRefLinker{}
+
+	for i, test := range []struct {
+		args   []interface{}
+		expect interface{}
+	}{
+		{[]interface{}{"about.md"}, "about.md"},
+		{[]interface{}{"about.md", "json"}, "about.md|json"},
+		{[]interface{}{map[string]interface{}{"path": "about.md"}}, "about.md"},
+		{[]interface{}{map[string]interface{}{"path": "about.md", "outputFormat": "json"}}, "about.md|json"},
+		{[]interface{}{map[string]interface{}{"path": "about.md", "foo": "bar"}}, false},
+		{[]interface{}{tstNoStringer{}}, false},
+	} {
+		errMsg := fmt.Sprintf("[%d] %v", i, test.args)
+
+		ref, err := ns.Ref(p, test.args...)
+		relRef, relErr := ns.RelRef(p, test.args...)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			require.Error(t, err, errMsg)
+			require.Error(t, relErr, errMsg)
+			continue
+		}
+
+		require.NoError(t, err, errMsg)
+		require.NoError(t, relErr, errMsg)
+		assert.Equal(t, template.HTML("Ref:"+test.expect.(string)), ref, errMsg)
+		assert.Equal(t, template.HTML("RelRef:"+test.expect.(string)), relRef, errMsg)
+	}
+
+	_, err := ns.Ref("not a page", "about.md")
+	require.Error(t, err)
+}
@@ -44,7 +44,7 @@ type absurllexer struct {
 	matchers []absURLMatcher
 
 	ms      matchState
-	matches [3]bool // track matches of the 3 prefixes
+	matches [4]bool // track matches of the 4 prefixes
 	idx     int     // last index in matches checked
 
 }
@@ -64,8 +64,16 @@ var prefixes = []*prefix{
 	{r: []rune{'s', 'r', 'c', '='}, f: checkCandidateBase},
 	{r: []rune{'h', 'r', 'e', 'f', '='}, f: checkCandidateBase},
 	{r: []rune{'s', 'r', 'c', 's', 'e', 't', '='}, f: checkCandidateSrcset},
+	{r: []rune{'s', 't', 'y', 'l', 'e', '='}, f: checkCandidateStyle},
 }
 
+var (
+	cssURLStart = []byte("url(")
+
+	// The quotes allowed around a URL in CSS, also when escaped.
+	cssURLQuotes = [][]byte{[]byte("\""), []byte("'"), []byte("&#34;"), []byte("&#39;"), []byte("&quot;")}
+)
+
 type absURLMatcher struct {
 	match []byte
 	quote []byte
@@ -166,20 +174,21 @@ func checkCandidateBase(l *absurllexer) {
 
 // handle URLs in srcset.
 func checkCandidateSrcset(l *absurllexer) {
+	checkCandidateAttr(l, replaceSrcsetURLs)
+}
+
+// handle url() references in style.
+func checkCandidateStyle(l *absurllexer) {
+	checkCandidateAttr(l, replaceStyleURLs)
+}
+
+// checkCandidateAttr handles attributes that may hold several URLs. The
+// quoted attribute value is passed to replace, which writes it with its
+// root relative URLs prefixed with path.
+func checkCandidateAttr(l *absurllexer, replace func(w io.Writer, section, path []byte)) {
 	// special case, not frequent (me think)
 	for _, m := range l.matchers {
-		if !bytes.HasPrefix(l.content[l.pos:], m.match) {
-			continue
-		}
-
-		// check for schemaless URLs
-		posAfter := l.pos + len(m.match)
-		if posAfter >= len(l.content) {
-			return
-		}
-		r, _ := utf8.DecodeRune(l.content[posAfter:])
-		if r == '/' {
-			// schemaless: skip
+		if !bytes.HasPrefix(l.content[l.pos:], m.quote) {
 			continue
 		}
 
@@ -196,26 +205,83 @@ func checkCandidateSrcset(l *absurllexer) {
 
 		section := l.content[l.pos+len(m.quote) : l.pos+posLastQuote+1]
 
-		fields := bytes.Fields(section)
 		l.w.Write(m.quote)
-		for i, f := range fields {
-			if f[0] == '/' {
-				l.w.Write(l.path)
-				l.w.Write(f[1:])
+		replace(l.w, section, l.path)
+		l.w.Write(m.quote)
 
-			} else {
-				l.w.Write(f)
+		l.pos += len(section) + (len(m.quote) * 2)
+		l.start = l.pos
+		return
+	}
+}
+
+// replaceSrcsetURLs writes the image candidates in the srcset value section
+// to w. A candidate is a URL followed by an optional descriptor, and the
+// candidates are separated by commas.
+func replaceSrcsetURLs(w io.Writer, section, path []byte) {
+	start := 0
+	urlStart := true
+
+	for i, c := range section {
+		switch {
+		case urlStart && (c == ',' || isSpace(c)):
+		case urlStart:
+			urlStart = false
+			if isRootRelative(section[i:]) {
+				w.Write(section[start:i])
+				w.Write(path)
+				start = i + 1
 			}
+		case c == ',':
+			urlStart = true
+		}
+	}
 
-			if i < len(fields)-1 {
-				l.w.Write([]byte(" "))
+	w.Write(section[start:])
+}
+
+// replaceStyleURLs writes the CSS in the style value section to w.
+func replaceStyleURLs(w io.Writer, section, path []byte) {
+	start := 0
+
+	for {
+		idx := bytes.Index(section[start:], cssURLStart)
+		if idx < 0 {
+			break
+		}
+
+		i := start + idx + len(cssURLStart)
+		for i < len(section) && isSpace(section[i]) {
+			i++
+		}
+		for _, q := range cssURLQuotes {
+			if bytes.HasPrefix(section[i:], q) {
+				i += len(q)
+				break
 			}
 		}
 
-		l.w.Write(m.quote)
-		l.pos += len(section) + (len(m.quote) * 2)
-		l.start = l.pos
+		if isRootRelative(section[i:]) {
+			w.Write(section[start:i])
+			w.Write(path)
+			i++
+		} else {
+			w.Write(section[start:i])
+		}
+		start = i
 	}
+
+	w.Write(section[start:])
+}
+
+// isRootRelative reports whether b starts with a root relative, and not a
+// schemaless, URL.
+func isRootRelative(b []byte) bool {
+	return len(b) > 0 && b[0] == '/' && (len(b) == 1 || b[1] != '/')
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
 }
 
 // main loop
@@ -89,6 +89,17 @@ schemaless: &lt;img srcset=&#39;//img.jpg&#39; src=&#39;//basic.jpg&#39;&gt;
 schemaless2: &lt;img srcset=&quot;//img.jpg&quot; src=&quot;//basic.jpg2&gt; POST
 `
 
+	srcsetMixed = `<img srcset="small.jpg 200w,/img/medium.jpg 300w, //cdn/big.jpg 700w,
+    /img/huge.jpg 1400w" src="/img/foo.jpg">`
+	srcsetMixedCorrect = `<img srcset="small.jpg 200w,http://base/img/medium.jpg 300w, //cdn/big.jpg 700w,
+    http://base/img/huge.jpg 1400w" src="http://base/img/foo.jpg">`
+
+	styleBasic        = `<div style="background-image: url(/img/bg.jpg); color: red"> <span style='background: url("/a.png"), url( /b.png ), url(//cdn/c.png), url(d.png)'>`
+	styleBasicCorrect = `<div style="background-image: url(http://base/img/bg.jpg); color: red"> <span style='background: url("http://base/a.png"), url( http://base/b.png ), url(//cdn/c.png), url(d.png)'>`
+	styleNoURL        = `<p style="color: red">/not/a/url</p>`
+	styleXML          = `&lt;div style=&#34;background: url(&#39;/img/bg.jpg&#39;)&#34;&gt;`
+	styleXMLCorrect   = `&lt;div style=&#34;background: url(&#39;http://base/img/bg.jpg&#39;)&#34;&gt;`
+
 	relPathVariations        = `PRE. a href="/img/small.jpg" POST.`
 	relPathVariationsCorrect = `PRE. a href="../../img/small.jpg" POST.`
 
@@ -113,7 +124,9 @@ var (
 	absURLTests    = append(absURLlBenchTests, append(sanityTests, extraTestsHTML...)...)
 	extraTestsXML  = []test{{replaceSchemalessXML, replaceSchemalessXMLCorrect}}
 	xmlAbsURLTests = append(xmlAbsURLBenchTests, append(sanityTests, extraTestsXML...)...)
-	srcsetTests    = []test{{srcsetBasic, srcsetBasicCorrect}, {srcsetSingleQuote, srcsetSingleQuoteCorrect}, {srcsetVariations, srcsetVariationsCorrect}}
+	srcsetTests    = []test{{srcsetBasic, srcsetBasicCorrect}, {srcsetSingleQuote, srcsetSingleQuoteCorrect}, {srcsetVariations, srcsetVariationsCorrect}, {srcsetMixed, srcsetMixedCorrect}}
+	styleTests     = []test{{styleBasic, styleBasicCorrect}, {styleNoURL, styleNoURL}}
+	styleXMLTests  = []test{{styleXML, styleXMLCorrect}}
 	srcsetXMLTests = []test{
 		{srcsetXMLBasic, srcsetXMLBasicCorrect},
 		{srcsetXMLSingleQuote, srcsetXMLSingleQuoteCorrect},
@@ -213,6 +226,18 @@ func TestAbsXMLURLSrcSet(t *testing.T) {
 	apply(t.Errorf, tr, srcsetXMLTests)
 }
 
+func TestAbsURLStyle(t *testing.T) {
+	tr := NewChain(AbsURL)
+
+	apply(t.Errorf, tr, styleTests)
+}
+
+func TestAbsXMLURLStyle(t *testing.T) {
+	tr := NewChain(AbsURLInXML)
+
+	apply(t.Errorf, tr, styleXMLTests)
+}
+
 func BenchmarkXMLAbsURL(b *testing.B) {
 	tr := NewChain(AbsURLInXML)
 